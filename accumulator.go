@@ -0,0 +1,108 @@
+// package money - Accumulator
+// Adding up a long run of Moneys with Add or Sum allocates a new decimal on
+// every step. An Accumulator keeps one running total instead, which matters
+// when you're totalling millions of rows for a report.
+
+package money
+
+import (
+	"github.com/shopspring/decimal"
+	"math/big"
+)
+
+var tenInt = big.NewInt(10)
+
+// Accumulator keeps a running total of Moneys in a single currency, reusing
+// its buffers between calls. The zero value is an empty Accumulator ready to
+// use; the first Money added fixes its currency.
+//
+// Example:
+//
+//	var acc Accumulator
+//	for _, m := range rows {
+//		if err := acc.Add(m); err != nil {
+//			return err
+//		}
+//	}
+//	total := acc.Sum()
+//
+// NOTE: An Accumulator isn't safe for concurrent use.
+type Accumulator struct {
+	currency *Currency
+	coef     big.Int
+	exp      int32
+	pow      big.Int
+	scratch  big.Int
+}
+
+// Add adds m to the total. It returns an ErrCurrencyMismatch error, leaving the
+// total as it was, if m isn't in the same currency as what's already been
+// added. AllowUnknownCurrencyOps is honoured as it is by Money.Add.
+func (a *Accumulator) Add(m Money) error {
+
+	m.ensureInitialized()
+
+	if a.currency == nil {
+		a.currency = m.currency
+		a.exp = m.amount.Exponent()
+	} else {
+		c, ok := Money{currency: a.currency}.currencyWith(m)
+		if !ok {
+			return newError(ErrCurrencyMismatch, nil, "Cannot add mismatched currencies m1[%s] m2[%s]", a.currency, m.currency)
+		}
+		a.currency = c
+	}
+
+	// Most coefficients fit an int64 and go through the scratch buffer, the
+	// rest come from Coefficient, which hands us our own copy. Either way it's
+	// fine to scale it in place.
+	var coef *big.Int
+	if v, ok := coefficientInt64(m.amount); ok {
+		coef = a.scratch.SetInt64(v)
+	} else {
+		coef = m.amount.Coefficient()
+	}
+	exp := m.amount.Exponent()
+
+	switch {
+	case exp < a.exp:
+		a.coef.Mul(&a.coef, a.pow10(a.exp-exp))
+		a.exp = exp
+	case exp > a.exp:
+		coef.Mul(coef, a.pow10(exp-a.exp))
+	}
+
+	a.coef.Add(&a.coef, coef)
+
+	return nil
+}
+
+// Sum returns the total so far. An empty Accumulator sums to zero in
+// UnknownCurrencyCode.
+func (a *Accumulator) Sum() Money {
+	if a.currency == nil {
+		return Money{amount: decimal.Zero, currency: getUnknownCurrency()}
+	}
+
+	return Money{
+		amount:   decimal.NewFromBigInt(&a.coef, a.exp),
+		currency: a.currency,
+	}
+}
+
+// Reset empties the Accumulator, including its currency, keeping the buffers
+// for reuse.
+func (a *Accumulator) Reset() {
+	a.currency = nil
+	a.coef.SetInt64(0)
+	a.exp = 0
+}
+
+// pow10 returns 10^n in the Accumulator's scratch buffer.
+func (a *Accumulator) pow10(n int32) *big.Int {
+	a.pow.SetInt64(1)
+	for i := int32(0); i < n; i++ {
+		a.pow.Mul(&a.pow, tenInt)
+	}
+	return &a.pow
+}
@@ -0,0 +1,98 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAccumulator(t *testing.T) {
+	tests := [][]string{
+		{"1.23", "4.5", "-6", "0.001"},
+		{"100", "1e2", "0.00001", "-99.99999"},
+		{"12345678901234.56789", "87654321098765.43211"},
+		{"-1.5", "1.5"},
+		{"0.25", "123456789012345678901234567890.5", "-0.001", "99999999999999.9"},
+		{"0"},
+	}
+
+	for _, test := range tests {
+		var acc Accumulator
+		ms := make([]Money, len(test))
+		for i, s := range test {
+			ms[i] = RequireFromString("USD", s)
+			if err := acc.Add(ms[i]); err != nil {
+				t.Errorf("%v: unexpected error %s", test, err)
+			}
+		}
+
+		got, expected := acc.Sum(), Sum(ms[0], ms[1:]...)
+		if got.String() != expected.String() || got.Exponent() != expected.Exponent() || got.currency.Code != "USD" {
+			t.Errorf("%v: expected USD %s, got %s %s", test, expected, got.currency, got)
+		}
+	}
+}
+
+func TestAccumulator_Currency(t *testing.T) {
+	var acc Accumulator
+
+	if s := acc.Sum(); s.String() != "0" || s.currency.Code != UnknownCurrencyCode {
+		t.Errorf("expected ??? 0 when empty, got %s %s", s.currency, s)
+	}
+
+	acc.Add(RequireFromString("USD", "10"))
+	if err := acc.Add(RequireFromString("EUR", "5")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if s := acc.Sum(); s.String() != "10" || s.currency.Code != "USD" {
+		t.Errorf("expected the total to be untouched, got %s %s", s.currency, s)
+	}
+
+	// Sum doesn't hand out the running total
+	s := acc.Sum()
+	acc.Add(RequireFromString("USD", "1"))
+	if s.String() != "10" || acc.Sum().String() != "11" {
+		t.Errorf("expected 10 and 11, got %s and %s", s, acc.Sum())
+	}
+
+	acc.Reset()
+	if err := acc.Add(RequireFromString("EUR", "5")); err != nil {
+		t.Errorf("unexpected error after Reset %s", err)
+	}
+	if s := acc.Sum(); s.String() != "5" || s.currency.Code != "EUR" {
+		t.Errorf("expected EUR 5, got %s %s", s.currency, s)
+	}
+}
+
+func benchmarkRows() []Money {
+	ms := make([]Money, 1000)
+	for i := range ms {
+		ms[i], _ = New("USD", int64(i*37%10000), -2)
+	}
+	return ms
+}
+
+func BenchmarkAccumulator(b *testing.B) {
+	ms := benchmarkRows()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var acc Accumulator
+		for _, m := range ms {
+			if err := acc.Add(m); err != nil {
+				b.Fatal(err)
+			}
+		}
+		acc.Sum()
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	ms := benchmarkRows()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		Sum(ms[0], ms[1:]...)
+	}
+}
@@ -0,0 +1,62 @@
+// package money - Receipt breakdown
+// Tax and tip each get rounded on their own, and the total has to match what's
+// printed above it, so it's built from the rounded lines rather than
+// calculated separately.
+
+package money
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Breakdown is a receipt: the subtotal, the tax and tip on it, and the total,
+// all in the subtotal's currency and rounded to its Fraction.
+//
+// Total is always exactly Subtotal + Tax + Tip, so any rounding ends up in it
+// rather than in a mismatch between the lines.
+type Breakdown struct {
+	Subtotal Money
+	Tax      Money
+	Tip      Money
+	Total    Money
+}
+
+// NewBreakdown works out the tax and tip on subtotal. The rates are
+// percentages, so 8.875 for 8.875% tax, as with SplitByPercent. The tip is on
+// the subtotal, not the subtotal plus tax. Each line is rounded to the
+// currency's Fraction using DefaultRoundingMode, and the total is the sum of
+// the rounded lines.
+//
+// Example:
+//
+//	b, _ := NewBreakdown(RequireFromString("USD", "50.00"), decimal.RequireFromString("8.875"), decimal.New(18, 0))
+//	// b.Tax: 4.44, b.Tip: 9.00, b.Total: 63.44
+//
+// An error is returned if either rate is negative.
+func NewBreakdown(subtotal Money, taxRate, tipRate decimal.Decimal) (Breakdown, error) {
+
+	subtotal.ensureInitialized()
+
+	if taxRate.Sign() < 0 {
+		return Breakdown{}, newError(ErrInvalidArgument, nil, "Cannot apply a negative tax rate [%s]", taxRate)
+	}
+	if tipRate.Sign() < 0 {
+		return Breakdown{}, newError(ErrInvalidArgument, nil, "Cannot apply a negative tip rate [%s]", tipRate)
+	}
+
+	b := Breakdown{Subtotal: subtotal.roundToFraction(DefaultRoundingMode)}
+	b.Tax = b.Subtotal.percentOf(taxRate)
+	b.Tip = b.Subtotal.percentOf(tipRate)
+	b.Total = b.Subtotal.AddMany(b.Tax, b.Tip)
+
+	return b, nil
+}
+
+// percentOf returns percent% of m, rounded to its Fraction using
+// DefaultRoundingMode.
+func (m Money) percentOf(percent decimal.Decimal) Money {
+	return Money{
+		amount:   unsignedZero(divRoundDecimal(m.amount.Mul(percent), decimal.New(100, 0), int32(m.currency.Fraction), DefaultRoundingMode)),
+		currency: m.currency,
+	}
+}
@@ -0,0 +1,48 @@
+package money
+
+import (
+	"github.com/shopspring/decimal"
+	"testing"
+)
+
+func TestNewBreakdown(t *testing.T) {
+	tests := []struct {
+		curr     string
+		subtotal string
+		tax      string
+		tip      string
+		expected [4]string
+	}{
+		{"USD", "50.00", "8.875", "18", [4]string{"50", "4.44", "9", "63.44"}},
+		{"USD", "19.99", "8.875", "15", [4]string{"19.99", "1.77", "3", "24.76"}},
+		{"USD", "10.005", "10", "0", [4]string{"10", "1", "0", "11"}},
+		{"USD", "-20.00", "8.875", "0", [4]string{"-20", "-1.78", "0", "-21.78"}},
+		{"JPY", "1234", "10", "0", [4]string{"1234", "123", "0", "1357"}},
+		{"KWD", "12.345", "5", "12.5", [4]string{"12.345", "0.617", "1.543", "14.505"}},
+	}
+
+	for _, test := range tests {
+		b, err := NewBreakdown(RequireFromString(test.curr, test.subtotal), decimal.RequireFromString(test.tax), decimal.RequireFromString(test.tip))
+		if err != nil {
+			t.Errorf("%s %s: unexpected error %s", test.curr, test.subtotal, err)
+			continue
+		}
+
+		lines := [4]Money{b.Subtotal, b.Tax, b.Tip, b.Total}
+		for i, line := range lines {
+			if line.String() != test.expected[i] || line.currency.Code != test.curr {
+				t.Errorf("%s %s: expected line %d to be %s %s, got %s %s", test.curr, test.subtotal, i, test.curr, test.expected[i], line.currency, line)
+			}
+		}
+
+		if !b.Subtotal.AddMany(b.Tax, b.Tip).Equal(b.Total) {
+			t.Errorf("%s %s: lines don't add up to %s", test.curr, test.subtotal, b.Total)
+		}
+	}
+
+	for _, rates := range [][2]string{{"-1", "0"}, {"0", "-1"}} {
+		if _, err := NewBreakdown(RequireFromString("USD", "1"), decimal.RequireFromString(rates[0]), decimal.RequireFromString(rates[1])); err == nil {
+			t.Errorf("%v: expected an error", rates)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// package money - Bucketing
+// Counting amounts into ranges, for histograms and reports. It comes up often
+// enough, and is fiddly enough at the edges, to be worth doing once.
+
+package money
+
+import (
+	"sort"
+)
+
+// Bucketize counts how many of values fall into each of the ranges marked out
+// by edges, which must be in ascending order. Each range includes its lower
+// edge but not its upper one. The first count is for values below the first
+// edge, and the last for values at or above the last edge, so there is always
+// one more count than there are edges.
+//
+// Example:
+//
+//	edges := []Money{
+//		RequireFromString("USD", "0"),
+//		RequireFromString("USD", "10"),
+//		RequireFromString("USD", "100"),
+//	}
+//	Bucketize(values, edges) // [below 0, 0 up to 10, 10 up to 100, 100 and up]
+//
+// An error is returned if there are no edges, they aren't strictly ascending,
+// or any of values or edges are in a different currency to the first edge.
+func Bucketize(values []Money, edges []Money) ([]int, error) {
+
+	if len(edges) == 0 {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot bucketize without any edges")
+	}
+
+	// A copy, so initializing them doesn't touch the caller's
+	edges = append([]Money(nil), edges...)
+	for i := range edges {
+		edges[i].ensureInitialized()
+	}
+
+	for i := 1; i < len(edges); i++ {
+		if _, ok := edges[0].currencyWith(edges[i]); !ok {
+			return nil, newError(ErrCurrencyMismatch, nil, "Cannot bucketize with mismatched edge currencies m1[%s] m2[%s]", edges[0].currency, edges[i].currency)
+		}
+		if edges[i].amount.Cmp(edges[i-1].amount) <= 0 {
+			return nil, newError(ErrInvalidArgument, nil, "Cannot bucketize, edge [%d] %s isn't above edge [%d] %s", i, edges[i], i-1, edges[i-1])
+		}
+	}
+
+	counts := make([]int, len(edges)+1)
+	for i, v := range values {
+		v.ensureInitialized()
+
+		if _, ok := edges[0].currencyWith(v); !ok {
+			return nil, newError(ErrCurrencyMismatch, nil, "Cannot bucketize value [%d] with a mismatched currency m1[%s] m2[%s]", i, edges[0].currency, v.currency)
+		}
+
+		// The number of edges at or below v is its bucket
+		b := sort.Search(len(edges), func(j int) bool {
+			return edges[j].amount.Cmp(v.amount) > 0
+		})
+		counts[b]++
+	}
+
+	return counts, nil
+}
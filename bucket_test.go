@@ -0,0 +1,72 @@
+package money
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func usdAmounts(ss ...string) []Money {
+	ms := make([]Money, len(ss))
+	for i, s := range ss {
+		ms[i] = RequireFromString("USD", s)
+	}
+	return ms
+}
+
+func TestBucketize(t *testing.T) {
+	edges := usdAmounts("0", "10", "100")
+
+	tests := []struct {
+		values   []Money
+		expected []int
+	}{
+		{usdAmounts("-5", "0", "9.99", "10", "50", "99.999", "100", "1000"), []int{1, 2, 3, 2}},
+		{usdAmounts("-0.01", "-100"), []int{2, 0, 0, 0}},
+		{usdAmounts("100.00", "1e6"), []int{0, 0, 0, 2}},
+		{nil, []int{0, 0, 0, 0}},
+	}
+
+	for _, test := range tests {
+		got, err := Bucketize(test.values, edges)
+		if err != nil {
+			t.Errorf("%v: unexpected error %s", test.values, err)
+		} else if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%v: expected %v got %v", test.values, test.expected, got)
+		}
+	}
+
+	// A single edge just splits below and above
+	if got, _ := Bucketize(usdAmounts("1", "2", "3"), usdAmounts("2")); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("expected [1 2], got %v", got)
+	}
+}
+
+func TestBucketize_Errors(t *testing.T) {
+	values := usdAmounts("1", "2")
+
+	for _, edges := range [][]Money{
+		nil,
+		usdAmounts("10", "0"),
+		usdAmounts("0", "10", "10"),
+	} {
+		if _, err := Bucketize(values, edges); err == nil {
+			t.Errorf("%v: expected an error", edges)
+		}
+	}
+
+	edges := append(usdAmounts("0"), RequireFromString("EUR", "10"))
+	if _, err := Bucketize(values, edges); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch for the edges, got %v", err)
+	}
+
+	values = append(values, RequireFromString("EUR", "5"))
+	if _, err := Bucketize(values, usdAmounts("0", "10")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch for the values, got %v", err)
+	}
+
+	// The zero value is in UnknownCurrencyCode
+	if _, err := Bucketize([]Money{{}}, usdAmounts("0")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch for the zero value, got %v", err)
+	}
+}
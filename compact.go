@@ -0,0 +1,175 @@
+// package money - Compact binary
+// MarshalBinary leans on gob for the coefficient, which is bulky and slow for
+// something as small as an amount. Bytes is a plain layout for hot paths such
+// as caches and queues.
+
+package money
+
+import (
+	"encoding/binary"
+	"github.com/shopspring/decimal"
+	"math/big"
+)
+
+// Bytes returns m in a compact binary layout, smaller and quicker to write
+// than MarshalBinary. FromBytes reads it back.
+//
+// The layout is:
+//
+//	[uvarint length][currency code][varint exponent][uvarint length][coefficient]
+//
+// where the coefficient is big endian two's complement, in as few bytes as
+// it takes. Zero is written as no bytes at all.
+//
+// NOTE: The two layouts aren't interchangeable; something written by Bytes
+// can't be read by UnmarshalBinary, or the other way round.
+func (m Money) Bytes() []byte {
+	m.ensureInitialized()
+
+	// Most amounts fit an int64, which can go straight into the output
+	// without a buffer of its own
+	var coef []byte
+	v, small := coefficientInt64(m.amount)
+	if !small {
+		if co := m.amount.Coefficient(); co.IsInt64() {
+			v, small = co.Int64(), true
+		} else {
+			coef = twosComplement(co)
+		}
+	}
+	size := len(coef)
+	if small {
+		size = int64Len(v)
+	}
+
+	data := make([]byte, 3*binary.MaxVarintLen64+len(m.currency.Code)+size)
+	n := binary.PutUvarint(data, uint64(len(m.currency.Code)))
+	n += copy(data[n:], m.currency.Code)
+	n += binary.PutVarint(data[n:], int64(m.amount.Exponent()))
+	n += binary.PutUvarint(data[n:], uint64(size))
+	if small {
+		for i := n + size - 1; i >= n; i-- {
+			data[i] = byte(v)
+			v >>= 8
+		}
+		n += size
+	} else {
+		n += copy(data[n:], coef)
+	}
+
+	return data[:n]
+}
+
+// FromBytes reads a Money written by Bytes.
+//
+// An error is returned if data is truncated, has anything left over, or the
+// currency isn't known.
+func FromBytes(data []byte) (Money, error) {
+
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n > uint64(len(data)-k) {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, nil, "Cannot read currency from [%d] bytes", len(data))
+	}
+	code, data := data[k:k+int(n)], data[k+int(n):]
+
+	exp, k := binary.Varint(data)
+	if k <= 0 || exp != int64(int32(exp)) {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, nil, "Cannot read exponent from [%d] bytes", len(data))
+	}
+	data = data[k:]
+
+	n, k = binary.Uvarint(data)
+	if k <= 0 || n > uint64(len(data)-k) {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, nil, "Cannot read coefficient from [%d] bytes", len(data))
+	}
+	if extra := len(data) - k - int(n); extra > 0 {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, nil, "Unexpected [%d] bytes after coefficient", extra)
+	}
+	data = data[k:]
+
+	// Looked up directly, so the code isn't copied into a string
+	c, ok := currencies[string(code)]
+	if !ok {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: string(code)}
+	}
+
+	if len(data) <= 8 {
+		return Money{amount: decimal.New(int64FromTwosComplement(data), int32(exp)), currency: c}, nil
+	}
+	return Money{amount: decimal.NewFromBigInt(fromTwosComplement(data), int32(exp)), currency: c}, nil
+}
+
+// int64Len returns how many bytes twosComplement would use for v.
+func int64Len(v int64) int {
+	if v == 0 {
+		return 0
+	}
+
+	n := 1
+	for v < -128 || v > 127 {
+		v >>= 8
+		n++
+	}
+	return n
+}
+
+// int64FromTwosComplement is the reverse of twosComplement, for up to 8 bytes.
+func int64FromTwosComplement(b []byte) int64 {
+	var v int64
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v = -1
+	}
+	for _, c := range b {
+		v = v<<8 | int64(c)
+	}
+	return v
+}
+
+// twosComplement returns x as big endian two's complement, using the fewest
+// bytes that keep its sign.
+func twosComplement(x *big.Int) []byte {
+	if x.Sign() == 0 {
+		return nil
+	}
+
+	// One spare byte up front, in case the sign needs it
+	b := make([]byte, 1+(x.BitLen()+7)/8)
+	x.FillBytes(b[1:])
+
+	if x.Sign() < 0 {
+		negate(b[1:])
+		b[0] = 0xff
+	}
+
+	// Drop the spare byte if the next one already has the right sign bit
+	if (b[0] == 0) == (b[1]&0x80 == 0) {
+		b = b[1:]
+	}
+	return b
+}
+
+// fromTwosComplement is the reverse of twosComplement.
+func fromTwosComplement(b []byte) *big.Int {
+	x := new(big.Int)
+	if len(b) == 0 || b[0]&0x80 == 0 {
+		return x.SetBytes(b)
+	}
+
+	mag := make([]byte, len(b))
+	copy(mag, b)
+	negate(mag)
+	return x.Neg(x.SetBytes(mag))
+}
+
+// negate flips the sign of the big endian two's complement number in b, in
+// place.
+func negate(b []byte) {
+	carry := true
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = ^b[i]
+		if carry {
+			b[i]++
+			carry = b[i] == 0
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package money
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestBytes_RoundTrip(t *testing.T) {
+	AddCurrencyFull(Currency{Type: POINTS, Code: "LONGCODE", Fraction: 2})
+	defer delete(currencies, "LONGCODE")
+
+	tests := []struct {
+		curr   string
+		amount string
+	}{
+		{"USD", "0"},
+		{"USD", "123.45"},
+		{"USD", "-123.45"},
+		{"USD", "1.28"},
+		{"USD", "-1.28"},
+		{"USD", "-2.56"},
+		{"USD", "-0.01"},
+		{"JPY", "1000"},
+		{"EUR", "-0.000000000000000000000000000001"},
+		{"BTC", "123456789012345678901234567890.123456789012345678901234567890"},
+		{"BTC", "-123456789012345678901234567890.123456789012345678901234567890"},
+		{"LONGCODE", "1e30"},
+		{"USD", "9007199254740993"},
+		{"USD", "999999999999999999"},
+		{"USD", "-1000000000000000000"},
+		{"USD", "9223372036854775807"},
+		{"USD", "-9223372036854775808"},
+		{"USD", "9223372036854775808"},
+		{UnknownCurrencyCode, "42"},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString(test.curr, test.amount)
+
+		got, err := FromBytes(m.Bytes())
+		if err != nil {
+			t.Errorf("%s %s: unexpected error %s", test.curr, test.amount, err)
+			continue
+		}
+		if got.currency.Code != test.curr || got.amount.Cmp(m.amount) != 0 || got.Exponent() != m.Exponent() {
+			t.Errorf("%s %s: got %s %s", test.curr, test.amount, got.currency, got)
+		}
+	}
+}
+
+func TestBytes_Layout(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected []byte
+	}{
+		// 3 "USD", exponent -2 zigzagged, then the coefficient
+		{"123.45", []byte{3, 'U', 'S', 'D', 3, 2, 0x30, 0x39}},
+		{"-123.45", []byte{3, 'U', 'S', 'D', 3, 2, 0xcf, 0xc7}},
+		{"1.28", []byte{3, 'U', 'S', 'D', 3, 2, 0x00, 0x80}},
+		{"-1.28", []byte{3, 'U', 'S', 'D', 3, 1, 0x80}},
+		{"-0.01", []byte{3, 'U', 'S', 'D', 3, 1, 0xff}},
+		{"0", []byte{3, 'U', 'S', 'D', 0, 0}},
+	}
+
+	for _, test := range tests {
+		if b := RequireFromString("USD", test.amount).Bytes(); !bytes.Equal(b, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.amount, test.expected, b)
+		}
+	}
+
+	// The int64 shortcut writes the same coefficient as the big.Int route
+	for _, v := range []int64{1, -1, 127, 128, -128, -129, 255, -256, 1 << 53, -(1 << 62), 999999999999999999} {
+		m, _ := New("USD", v, 0)
+		expected := twosComplement(big.NewInt(v))
+		if b := m.Bytes(); int(b[5]) != len(expected) || !bytes.Equal(b[6:], expected) {
+			t.Errorf("%d: expected %v, got %v", v, expected, b[5:])
+		}
+	}
+
+	m := RequireFromString("USD", "-123456789.123456789")
+	b, _ := m.MarshalBinary()
+	if len(m.Bytes()) >= len(b) {
+		t.Errorf("expected Bytes to be smaller than MarshalBinary, got %d and %d bytes", len(m.Bytes()), len(b))
+	}
+}
+
+func TestFromBytes_Errors(t *testing.T) {
+	good := RequireFromString("USD", "-123.45").Bytes()
+
+	tests := [][]byte{
+		nil,
+		{3, 'U', 'S'},
+		good[:4],
+		good[:5],
+		good[:len(good)-1],
+		append(append([]byte{}, good...), 0),
+		{3, 'U', 'S', 'D', 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01, 0},
+	}
+
+	for _, data := range tests {
+		if _, err := FromBytes(data); !errors.Is(err, ErrParse) {
+			t.Errorf("%v: expected ErrParse, got %v", data, err)
+		}
+	}
+
+	if _, err := FromBytes([]byte{3, 'X', 'Y', 'Z', 0, 0}); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func BenchmarkBytes(b *testing.B) {
+	m := RequireFromString("USD", "-123456789.123456789")
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		FromBytes(m.Bytes())
+	}
+}
+
+func BenchmarkMarshalBinary(b *testing.B) {
+	m := RequireFromString("USD", "-123456789.123456789")
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		data, _ := m.MarshalBinary()
+		var m2 Money
+		m2.UnmarshalBinary(data)
+	}
+}
@@ -6,6 +6,7 @@
 package money
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -25,6 +26,27 @@ const (
 	UNKNOWN = 9999 //  UNKNOWN    (Testing currencies. Should never see in production)
 )
 
+// GroupingStyle is how the digits of the integer part are split up by the
+// Thousand separator.
+type GroupingStyle int
+
+// Grouping styles available.
+const (
+	GroupWestern GroupingStyle = iota //	GroupWestern	(groups of three. 1,234,567)
+	GroupIndian                       //	GroupIndian	(the last three, then groups of two, as in lakh and crore. 12,34,567)
+)
+
+// SymbolSpacing is the gap between the grapheme and the amount.
+type SymbolSpacing int
+
+// Symbol spacings available.
+const (
+	SpacingTemplate  SymbolSpacing = iota //	SpacingTemplate	(whatever the template has. "$1" => $1,234.56, "1 $" => 1,234.56 $)
+	SpacingNone                           //	SpacingNone	(no gap. $1,234.56, 1,234.56$)
+	SpacingSpace                          //	SpacingSpace	(a space. $ 1,234.56, 1,234.56 $)
+	SpacingThinSpace                      //	SpacingThinSpace	(a thin space, U+2009. $\u20091,234.56)
+)
+
 // UnknownCurrencyCode is used when creating Money objects where we don't yet know the currency.
 const (
 	UnknownCurrencyCode = "???"
@@ -32,14 +54,40 @@ const (
 )
 
 // Currency represents money currency information required for formatting
+//
+// Name is the English name of the currency (ie. "US Dollar"). Use DisplayName
+// and Symbol rather than Name and Grapheme when labelling things, as they fall
+// back to the code when the field is empty.
+//
+// NumericCode is the ISO 4217 numeric code (ie. 840 for USD). MajorUnitName and
+// MinorUnitName are the singular names of the whole and fractional units (ie.
+// "dollar" and "cent"). These are left empty for currencies which don't have
+// them, like crypto and points.
+//
+// CashInterval is the interval cash payments are rounded to, as taken by
+// RoundCashInterval (ie. 5 for CHF, where the smallest coin is 5 centimes), or
+// 0 if cash isn't rounded.
+//
+// Grouping is how the Thousand separator splits up the amount, ie.
+// GroupIndian for INR.
+//
+// Spacing overrides the gap the template puts between the grapheme and the
+// amount. It's left as SpacingTemplate for every built in currency.
 type Currency struct {
-	Type     CurrType
-	Code     string
-	Fraction int
-	Grapheme string
-	Template string
-	DecPoint string
-	Thousand string
+	Type          CurrType
+	Code          string
+	Name          string
+	Fraction      int
+	Grapheme      string
+	Template      string
+	DecPoint      string
+	Thousand      string
+	Grouping      GroupingStyle
+	Spacing       SymbolSpacing
+	NumericCode   int
+	MajorUnitName string
+	MinorUnitName string
+	CashInterval  uint8
 }
 
 // currencies represents a collection of currency
@@ -47,139 +95,147 @@ type Currency struct {
 // If this changes, we'll need to fix the (Un)MarshallBinary functions as they'll break badly.
 var currencies = map[string]*Currency{
 	// Fiat Currencies
-	"AED": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AED", Fraction: 2, Grapheme: ".\u062f.\u0625", Template: "1 $"},
-	"AFN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AFN", Fraction: 2, Grapheme: "\u060b", Template: "1 $"},
-	"ALL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ALL", Fraction: 2, Grapheme: "L", Template: "$1"},
-	"AMD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AMD", Fraction: 2, Grapheme: "\u0564\u0580.", Template: "1 $"},
-	"ANG": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ANG", Fraction: 2, Grapheme: "\u0192", Template: "$1"},
-	"ARS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ARS", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"AUD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AUD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"AWG": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AWG", Fraction: 2, Grapheme: "\u0192", Template: "$1"},
-	"AZN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AZN", Fraction: 2, Grapheme: "\u20bc", Template: "$1"},
-	"BAM": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BAM", Fraction: 2, Grapheme: "KM", Template: "$1"},
-	"BBD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BBD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"BGN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BGN", Fraction: 2, Grapheme: "\u043b\u0432", Template: "$1"},
-	"BHD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BHD", Fraction: 3, Grapheme: ".\u062f.\u0628", Template: "1 $"},
-	"BMD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BMD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"BND": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BND", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"BOB": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BOB", Fraction: 2, Grapheme: "Bs.", Template: "$1"},
-	"BRL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BRL", Fraction: 2, Grapheme: "R$", Template: "$1"},
-	"BSD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BSD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"BWP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BWP", Fraction: 2, Grapheme: "P", Template: "$1"},
-	"BYN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BYN", Fraction: 2, Grapheme: "p.", Template: "1 $"},
-	"BYR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BYR", Fraction: 0, Grapheme: "p.", Template: "1 $"},
-	"BZD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BZD", Fraction: 2, Grapheme: "BZ$", Template: "$1"},
-	"CAD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CAD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"CLP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CLP", Fraction: 0, Grapheme: "$", Template: "$1"},
-	"CNY": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CNY", Fraction: 2, Grapheme: "\u5143", Template: "1 $"},
-	"COP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "COP", Fraction: 0, Grapheme: "$", Template: "$1"},
-	"CRC": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CRC", Fraction: 2, Grapheme: "\u20a1", Template: "$1"},
-	"CUP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CUP", Fraction: 2, Grapheme: "$MN", Template: "$1"},
-	"CZK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CZK", Fraction: 2, Grapheme: "K\u010d", Template: "1 $"},
-	"DKK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "DKK", Fraction: 2, Grapheme: "kr", Template: "1 $"},
-	"DOP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "DOP", Fraction: 2, Grapheme: "RD$", Template: "$1"},
-	"DZD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "DZD", Fraction: 2, Grapheme: ".\u062f.\u062c", Template: "1 $"},
-	"EEK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "EEK", Fraction: 2, Grapheme: "kr", Template: "$1"},
-	"EGP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "EGP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"EUR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "EUR", Fraction: 2, Grapheme: "\u20ac", Template: "$1"},
-	"FJD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "FJD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"FKP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "FKP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"GBP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GBP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"GGP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GGP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"GHC": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GHC", Fraction: 2, Grapheme: "\u00a2", Template: "$1"},
-	"GIP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GIP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"GTQ": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GTQ", Fraction: 2, Grapheme: "Q", Template: "$1"},
-	"GYD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GYD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"HKD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "HKD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"HNL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "HNL", Fraction: 2, Grapheme: "L", Template: "$1"},
-	"HRK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "HRK", Fraction: 2, Grapheme: "kn", Template: "$1"},
-	"HUF": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "HUF", Fraction: 0, Grapheme: "Ft", Template: "$1"},
-	"IDR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "IDR", Fraction: 2, Grapheme: "Rp", Template: "$1"},
-	"ILS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ILS", Fraction: 2, Grapheme: "\u20aa", Template: "$1"},
-	"IMP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "IMP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"INR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "INR", Fraction: 2, Grapheme: "\u20b9", Template: "$1"},
-	"IQD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "IQD", Fraction: 3, Grapheme: ".\u062f.\u0639", Template: "1 $"},
-	"IRR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "IRR", Fraction: 2, Grapheme: "\ufdfc", Template: "1 $"},
-	"ISK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ISK", Fraction: 2, Grapheme: "kr", Template: "$1"},
-	"JEP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "JEP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"JMD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "JMD", Fraction: 2, Grapheme: "J$", Template: "$1"},
-	"JOD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "JOD", Fraction: 3, Grapheme: ".\u062f.\u0625", Template: "1 $"},
-	"JPY": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "JPY", Fraction: 0, Grapheme: "\u00a5", Template: "$1"},
-	"KES": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KES", Fraction: 2, Grapheme: "KSh", Template: "$1"},
-	"KGS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KGS", Fraction: 2, Grapheme: "\u0441\u043e\u043c", Template: "$1"},
-	"KHR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KHR", Fraction: 2, Grapheme: "\u17db", Template: "$1"},
-	"KPW": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KPW", Fraction: 0, Grapheme: "\u20a9", Template: "$1"},
-	"KRW": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KRW", Fraction: 0, Grapheme: "\u20a9", Template: "$1"},
-	"KWD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KWD", Fraction: 3, Grapheme: ".\u062f.\u0643", Template: "1 $"},
-	"KYD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KYD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"KZT": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KZT", Fraction: 2, Grapheme: "\u20b8", Template: "$1"},
-	"LAK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LAK", Fraction: 2, Grapheme: "\u20ad", Template: "$1"},
-	"LBP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LBP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"LKR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LKR", Fraction: 2, Grapheme: "\u20a8", Template: "$1"},
-	"LRD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LRD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"LTL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LTL", Fraction: 2, Grapheme: "Lt", Template: "$1"},
-	"LVL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LVL", Fraction: 2, Grapheme: "Ls", Template: "1 $"},
-	"LYD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LYD", Fraction: 3, Grapheme: ".\u062f.\u0644", Template: "1 $"},
-	"MAD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MAD", Fraction: 2, Grapheme: ".\u062f.\u0645", Template: "1 $"},
-	"MKD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MKD", Fraction: 2, Grapheme: "\u0434\u0435\u043d", Template: "$1"},
-	"MNT": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MNT", Fraction: 2, Grapheme: "\u20ae", Template: "$1"},
-	"MUR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MUR", Fraction: 2, Grapheme: "\u20a8", Template: "$1"},
-	"MXN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MXN", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"MWK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MWK", Fraction: 2, Grapheme: "MK", Template: "$1"},
-	"MYR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MYR", Fraction: 2, Grapheme: "RM", Template: "$1"},
-	"MZN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MZN", Fraction: 2, Grapheme: "MT", Template: "$1"},
-	"NAD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NAD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"NGN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NGN", Fraction: 2, Grapheme: "\u20a6", Template: "$1"},
-	"NIO": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NIO", Fraction: 2, Grapheme: "C$", Template: "$1"},
-	"NOK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NOK", Fraction: 2, Grapheme: "kr", Template: "1 $"},
-	"NPR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NPR", Fraction: 2, Grapheme: "\u20a8", Template: "$1"},
-	"NZD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NZD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"OMR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "OMR", Fraction: 3, Grapheme: "\ufdfc", Template: "1 $"},
-	"PAB": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PAB", Fraction: 2, Grapheme: "B/.", Template: "$1"},
-	"PEN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PEN", Fraction: 2, Grapheme: "S/", Template: "$1"},
-	"PHP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PHP", Fraction: 2, Grapheme: "\u20b1", Template: "$1"},
-	"PKR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PKR", Fraction: 2, Grapheme: "\u20a8", Template: "$1"},
-	"PLN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PLN", Fraction: 2, Grapheme: "z\u0142", Template: "1 $"},
-	"PYG": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PYG", Fraction: 0, Grapheme: "Gs", Template: "1$"},
-	"QAR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "QAR", Fraction: 2, Grapheme: "\ufdfc", Template: "1 $"},
-	"RON": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "RON", Fraction: 2, Grapheme: "lei", Template: "$1"},
-	"RSD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "RSD", Fraction: 2, Grapheme: "\u0414\u0438\u043d.", Template: "$1"},
-	"RUB": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "RUB", Fraction: 2, Grapheme: "\u20bd", Template: "1 $"},
-	"RUR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "RUR", Fraction: 2, Grapheme: "\u20bd", Template: "1 $"},
-	"SAR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SAR", Fraction: 2, Grapheme: "\ufdfc", Template: "1 $"},
-	"SBD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SBD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"SCR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SCR", Fraction: 2, Grapheme: "\u20a8", Template: "$1"},
-	"SEK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SEK", Fraction: 2, Grapheme: "kr", Template: "1 $"},
-	"SGD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SGD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"SHP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SHP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"SOS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SOS", Fraction: 2, Grapheme: "S", Template: "$1"},
-	"SRD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SRD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"SVC": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SVC", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"SYP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SYP", Fraction: 2, Grapheme: "\u00a3", Template: "$1"},
-	"THB": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "THB", Fraction: 2, Grapheme: "\u0e3f", Template: "$1"},
-	"TND": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TND", Fraction: 3, Grapheme: ".\u062f.\u062a", Template: "1 $"},
-	"TRL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TRL", Fraction: 2, Grapheme: "\u20a4", Template: "$1"},
-	"TRY": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TRY", Fraction: 2, Grapheme: "\u20ba", Template: "$1"},
-	"TTD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TTD", Fraction: 2, Grapheme: "TT$", Template: "$1"},
-	"TWD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TWD", Fraction: 0, Grapheme: "NT$", Template: "$1"},
-	"TZS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TZS", Fraction: 0, Grapheme: "TSh", Template: "$1"},
-	"UAH": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "UAH", Fraction: 2, Grapheme: "\u20b4", Template: "$1"},
-	"UGX": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "UGX", Fraction: 0, Grapheme: "USh", Template: "$1"},
-	"USD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "USD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"UYU": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "UYU", Fraction: 0, Grapheme: "$U", Template: "$1"},
-	"UZS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "UZS", Fraction: 2, Grapheme: "so\u2019m", Template: "$1"},
-	"VEF": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "VEF", Fraction: 2, Grapheme: "Bs", Template: "$1"},
-	"VND": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "VND", Fraction: 0, Grapheme: "\u20ab", Template: "1 $"},
-	"XCD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "XCD", Fraction: 2, Grapheme: "$", Template: "$1"},
-	"YER": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "YER", Fraction: 2, Grapheme: "\ufdfc", Template: "1 $"},
-	"ZAR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ZAR", Fraction: 2, Grapheme: "R", Template: "$1"},
-	"ZMW": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ZMW", Fraction: 2, Grapheme: "ZK", Template: "$1"},
-	"ZWD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ZWD", Fraction: 2, Grapheme: "Z$", Template: "$1"},
+	"AED": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AED", Name: "UAE Dirham", Fraction: 2, Grapheme: ".\u062f.\u0625", Template: "1 $", NumericCode: 784, MajorUnitName: "dirham", MinorUnitName: "fils"},
+	"AFN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AFN", Name: "Afghan Afghani", Fraction: 2, Grapheme: "\u060b", Template: "1 $", NumericCode: 971, MajorUnitName: "afghani", MinorUnitName: "pul"},
+	"ALL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ALL", Name: "Albanian Lek", Fraction: 2, Grapheme: "L", Template: "$1", NumericCode: 8, MajorUnitName: "lek", MinorUnitName: "qindarka"},
+	"AMD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AMD", Name: "Armenian Dram", Fraction: 2, Grapheme: "\u0564\u0580.", Template: "1 $", NumericCode: 51, MajorUnitName: "dram", MinorUnitName: "luma"},
+	"ANG": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ANG", Name: "Netherlands Antillean Guilder", Fraction: 2, Grapheme: "\u0192", Template: "$1", NumericCode: 532, MajorUnitName: "guilder", MinorUnitName: "cent"},
+	"ARS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ARS", Name: "Argentine Peso", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 32, MajorUnitName: "peso", MinorUnitName: "centavo"},
+	"AUD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AUD", Name: "Australian Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 36, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"AWG": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AWG", Name: "Aruban Florin", Fraction: 2, Grapheme: "\u0192", Template: "$1", NumericCode: 533, MajorUnitName: "florin", MinorUnitName: "cent"},
+	"AZN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "AZN", Name: "Azerbaijani Manat", Fraction: 2, Grapheme: "\u20bc", Template: "$1", NumericCode: 944, MajorUnitName: "manat", MinorUnitName: "qapik"},
+	"BAM": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BAM", Name: "Bosnia-Herzegovina Convertible Mark", Fraction: 2, Grapheme: "KM", Template: "$1", NumericCode: 977, MajorUnitName: "mark", MinorUnitName: "fening"},
+	"BBD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BBD", Name: "Barbadian Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 52, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"BGN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BGN", Name: "Bulgarian Lev", Fraction: 2, Grapheme: "\u043b\u0432", Template: "$1", NumericCode: 975, MajorUnitName: "lev", MinorUnitName: "stotinka"},
+	"BHD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BHD", Name: "Bahraini Dinar", Fraction: 3, Grapheme: ".\u062f.\u0628", Template: "1 $", NumericCode: 48, MajorUnitName: "dinar", MinorUnitName: "fils"},
+	"BMD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BMD", Name: "Bermudan Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 60, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"BND": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BND", Name: "Brunei Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 96, MajorUnitName: "dollar", MinorUnitName: "sen"},
+	"BOB": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BOB", Name: "Bolivian Boliviano", Fraction: 2, Grapheme: "Bs.", Template: "$1", NumericCode: 68, MajorUnitName: "boliviano", MinorUnitName: "centavo"},
+	"BRL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BRL", Name: "Brazilian Real", Fraction: 2, Grapheme: "R$", Template: "$1", NumericCode: 986, MajorUnitName: "real", MinorUnitName: "centavo"},
+	"BSD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BSD", Name: "Bahamian Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 44, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"BWP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BWP", Name: "Botswanan Pula", Fraction: 2, Grapheme: "P", Template: "$1", NumericCode: 72, MajorUnitName: "pula", MinorUnitName: "thebe"},
+	"BYN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BYN", Name: "Belarusian Ruble", Fraction: 2, Grapheme: "p.", Template: "1 $", NumericCode: 933, MajorUnitName: "ruble", MinorUnitName: "kapeyka"},
+	"BYR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BYR", Name: "Belarusian Ruble (2000-2016)", Fraction: 0, Grapheme: "p.", Template: "1 $", NumericCode: 974, MajorUnitName: "ruble", MinorUnitName: "kapeyka"},
+	"BZD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "BZD", Name: "Belize Dollar", Fraction: 2, Grapheme: "BZ$", Template: "$1", NumericCode: 84, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"CAD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CAD", Name: "Canadian Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 124, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"CHF": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CHF", Name: "Swiss Franc", Fraction: 2, Grapheme: "CHF", Template: "$ 1", NumericCode: 756, MajorUnitName: "franc", MinorUnitName: "centime", CashInterval: 5},
+	"CLP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CLP", Name: "Chilean Peso", Fraction: 0, Grapheme: "$", Template: "$1", NumericCode: 152, MajorUnitName: "peso", MinorUnitName: "centavo"},
+	"CNY": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CNY", Name: "Chinese Yuan", Fraction: 2, Grapheme: "\u5143", Template: "1 $", NumericCode: 156, MajorUnitName: "yuan", MinorUnitName: "fen"},
+	"COP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "COP", Name: "Colombian Peso", Fraction: 0, Grapheme: "$", Template: "$1", NumericCode: 170, MajorUnitName: "peso", MinorUnitName: "centavo"},
+	"CRC": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CRC", Name: "Costa Rican Colon", Fraction: 2, Grapheme: "\u20a1", Template: "$1", NumericCode: 188, MajorUnitName: "colon", MinorUnitName: "centimo"},
+	"CUP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CUP", Name: "Cuban Peso", Fraction: 2, Grapheme: "$MN", Template: "$1", NumericCode: 192, MajorUnitName: "peso", MinorUnitName: "centavo"},
+	"CZK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "CZK", Name: "Czech Koruna", Fraction: 2, Grapheme: "K\u010d", Template: "1 $", NumericCode: 203, MajorUnitName: "koruna", MinorUnitName: "haler"},
+	"DKK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "DKK", Name: "Danish Krone", Fraction: 2, Grapheme: "kr", Template: "1 $", NumericCode: 208, MajorUnitName: "krone", MinorUnitName: "ore"},
+	"DOP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "DOP", Name: "Dominican Peso", Fraction: 2, Grapheme: "RD$", Template: "$1", NumericCode: 214, MajorUnitName: "peso", MinorUnitName: "centavo"},
+	"DZD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "DZD", Name: "Algerian Dinar", Fraction: 2, Grapheme: ".\u062f.\u062c", Template: "1 $", NumericCode: 12, MajorUnitName: "dinar", MinorUnitName: "santeem"},
+	"EEK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "EEK", Name: "Estonian Kroon", Fraction: 2, Grapheme: "kr", Template: "$1", NumericCode: 233, MajorUnitName: "kroon", MinorUnitName: "sent"},
+	"EGP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "EGP", Name: "Egyptian Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", NumericCode: 818, MajorUnitName: "pound", MinorUnitName: "piastre"},
+	"EUR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "EUR", Name: "Euro", Fraction: 2, Grapheme: "\u20ac", Template: "$1", NumericCode: 978, MajorUnitName: "euro", MinorUnitName: "cent"},
+	"FJD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "FJD", Name: "Fijian Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 242, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"FKP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "FKP", Name: "Falkland Islands Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", NumericCode: 238, MajorUnitName: "pound", MinorUnitName: "penny"},
+	"GBP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GBP", Name: "British Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", NumericCode: 826, MajorUnitName: "pound", MinorUnitName: "penny"},
+	"GGP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GGP", Name: "Guernsey Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", MajorUnitName: "pound", MinorUnitName: "penny"},
+	"GHC": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GHC", Name: "Ghanaian Cedi (1979-2007)", Fraction: 2, Grapheme: "\u00a2", Template: "$1", NumericCode: 288, MajorUnitName: "cedi", MinorUnitName: "pesewa"},
+	"GIP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GIP", Name: "Gibraltar Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", NumericCode: 292, MajorUnitName: "pound", MinorUnitName: "penny"},
+	"GTQ": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GTQ", Name: "Guatemalan Quetzal", Fraction: 2, Grapheme: "Q", Template: "$1", NumericCode: 320, MajorUnitName: "quetzal", MinorUnitName: "centavo"},
+	"GYD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "GYD", Name: "Guyanaese Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 328, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"HKD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "HKD", Name: "Hong Kong Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 344, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"HNL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "HNL", Name: "Honduran Lempira", Fraction: 2, Grapheme: "L", Template: "$1", NumericCode: 340, MajorUnitName: "lempira", MinorUnitName: "centavo"},
+	"HRK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "HRK", Name: "Croatian Kuna", Fraction: 2, Grapheme: "kn", Template: "$1", NumericCode: 191, MajorUnitName: "kuna", MinorUnitName: "lipa"},
+	"HUF": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "HUF", Name: "Hungarian Forint", Fraction: 0, Grapheme: "Ft", Template: "$1", NumericCode: 348, MajorUnitName: "forint", MinorUnitName: "filler"},
+	"IDR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "IDR", Name: "Indonesian Rupiah", Fraction: 2, Grapheme: "Rp", Template: "$1", NumericCode: 360, MajorUnitName: "rupiah", MinorUnitName: "sen"},
+	"ILS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ILS", Name: "Israeli New Shekel", Fraction: 2, Grapheme: "\u20aa", Template: "$1", NumericCode: 376, MajorUnitName: "shekel", MinorUnitName: "agora"},
+	"IMP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "IMP", Name: "Manx Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", MajorUnitName: "pound", MinorUnitName: "penny"},
+	"INR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "INR", Name: "Indian Rupee", Fraction: 2, Grapheme: "\u20b9", Template: "$1", Grouping: GroupIndian, NumericCode: 356, MajorUnitName: "rupee", MinorUnitName: "paisa"},
+	"IQD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "IQD", Name: "Iraqi Dinar", Fraction: 3, Grapheme: ".\u062f.\u0639", Template: "1 $", NumericCode: 368, MajorUnitName: "dinar", MinorUnitName: "fils"},
+	"IRR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "IRR", Name: "Iranian Rial", Fraction: 2, Grapheme: "\ufdfc", Template: "1 $", NumericCode: 364, MajorUnitName: "rial", MinorUnitName: "dinar"},
+	"ISK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ISK", Name: "Icelandic Krona", Fraction: 2, Grapheme: "kr", Template: "$1", NumericCode: 352, MajorUnitName: "krona", MinorUnitName: "eyrir"},
+	"JEP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "JEP", Name: "Jersey Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", MajorUnitName: "pound", MinorUnitName: "penny"},
+	"JMD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "JMD", Name: "Jamaican Dollar", Fraction: 2, Grapheme: "J$", Template: "$1", NumericCode: 388, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"JOD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "JOD", Name: "Jordanian Dinar", Fraction: 3, Grapheme: ".\u062f.\u0625", Template: "1 $", NumericCode: 400, MajorUnitName: "dinar", MinorUnitName: "fils"},
+	"JPY": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "JPY", Name: "Japanese Yen", Fraction: 0, Grapheme: "\u00a5", Template: "$1", NumericCode: 392, MajorUnitName: "yen", MinorUnitName: "sen"},
+	"KES": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KES", Name: "Kenyan Shilling", Fraction: 2, Grapheme: "KSh", Template: "$1", NumericCode: 404, MajorUnitName: "shilling", MinorUnitName: "cent"},
+	"KGS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KGS", Name: "Kyrgystani Som", Fraction: 2, Grapheme: "\u0441\u043e\u043c", Template: "$1", NumericCode: 417, MajorUnitName: "som", MinorUnitName: "tyiyn"},
+	"KHR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KHR", Name: "Cambodian Riel", Fraction: 2, Grapheme: "\u17db", Template: "$1", NumericCode: 116, MajorUnitName: "riel", MinorUnitName: "sen"},
+	"KPW": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KPW", Name: "North Korean Won", Fraction: 0, Grapheme: "\u20a9", Template: "$1", NumericCode: 408, MajorUnitName: "won", MinorUnitName: "chon"},
+	"KRW": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KRW", Name: "South Korean Won", Fraction: 0, Grapheme: "\u20a9", Template: "$1", NumericCode: 410, MajorUnitName: "won", MinorUnitName: "jeon"},
+	"KWD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KWD", Name: "Kuwaiti Dinar", Fraction: 3, Grapheme: ".\u062f.\u0643", Template: "1 $", NumericCode: 414, MajorUnitName: "dinar", MinorUnitName: "fils"},
+	"KYD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KYD", Name: "Cayman Islands Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 136, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"KZT": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "KZT", Name: "Kazakhstani Tenge", Fraction: 2, Grapheme: "\u20b8", Template: "$1", NumericCode: 398, MajorUnitName: "tenge", MinorUnitName: "tiyn"},
+	"LAK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LAK", Name: "Laotian Kip", Fraction: 2, Grapheme: "\u20ad", Template: "$1", NumericCode: 418, MajorUnitName: "kip", MinorUnitName: "att"},
+	"LBP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LBP", Name: "Lebanese Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", NumericCode: 422, MajorUnitName: "pound", MinorUnitName: "piastre"},
+	"LKR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LKR", Name: "Sri Lankan Rupee", Fraction: 2, Grapheme: "\u20a8", Template: "$1", NumericCode: 144, MajorUnitName: "rupee", MinorUnitName: "cent"},
+	"LRD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LRD", Name: "Liberian Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 430, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"LTL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LTL", Name: "Lithuanian Litas", Fraction: 2, Grapheme: "Lt", Template: "$1", NumericCode: 440, MajorUnitName: "litas", MinorUnitName: "centas"},
+	"LVL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LVL", Name: "Latvian Lats", Fraction: 2, Grapheme: "Ls", Template: "1 $", NumericCode: 428, MajorUnitName: "lats", MinorUnitName: "santims"},
+	"LYD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "LYD", Name: "Libyan Dinar", Fraction: 3, Grapheme: ".\u062f.\u0644", Template: "1 $", NumericCode: 434, MajorUnitName: "dinar", MinorUnitName: "dirham"},
+	"MAD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MAD", Name: "Moroccan Dirham", Fraction: 2, Grapheme: ".\u062f.\u0645", Template: "1 $", NumericCode: 504, MajorUnitName: "dirham", MinorUnitName: "centime"},
+	"MKD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MKD", Name: "Macedonian Denar", Fraction: 2, Grapheme: "\u0434\u0435\u043d", Template: "$1", NumericCode: 807, MajorUnitName: "denar", MinorUnitName: "deni"},
+	"MNT": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MNT", Name: "Mongolian Tugrik", Fraction: 2, Grapheme: "\u20ae", Template: "$1", NumericCode: 496, MajorUnitName: "tugrik", MinorUnitName: "mongo"},
+	"MUR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MUR", Name: "Mauritian Rupee", Fraction: 2, Grapheme: "\u20a8", Template: "$1", NumericCode: 480, MajorUnitName: "rupee", MinorUnitName: "cent"},
+	"MXN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MXN", Name: "Mexican Peso", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 484, MajorUnitName: "peso", MinorUnitName: "centavo"},
+	"MWK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MWK", Name: "Malawian Kwacha", Fraction: 2, Grapheme: "MK", Template: "$1", NumericCode: 454, MajorUnitName: "kwacha", MinorUnitName: "tambala"},
+	"MYR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MYR", Name: "Malaysian Ringgit", Fraction: 2, Grapheme: "RM", Template: "$1", NumericCode: 458, MajorUnitName: "ringgit", MinorUnitName: "sen"},
+	"MZN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "MZN", Name: "Mozambican Metical", Fraction: 2, Grapheme: "MT", Template: "$1", NumericCode: 943, MajorUnitName: "metical", MinorUnitName: "centavo"},
+	"NAD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NAD", Name: "Namibian Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 516, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"NGN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NGN", Name: "Nigerian Naira", Fraction: 2, Grapheme: "\u20a6", Template: "$1", NumericCode: 566, MajorUnitName: "naira", MinorUnitName: "kobo"},
+	"NIO": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NIO", Name: "Nicaraguan Cordoba", Fraction: 2, Grapheme: "C$", Template: "$1", NumericCode: 558, MajorUnitName: "cordoba", MinorUnitName: "centavo"},
+	"NOK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NOK", Name: "Norwegian Krone", Fraction: 2, Grapheme: "kr", Template: "1 $", NumericCode: 578, MajorUnitName: "krone", MinorUnitName: "ore"},
+	"NPR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NPR", Name: "Nepalese Rupee", Fraction: 2, Grapheme: "\u20a8", Template: "$1", NumericCode: 524, MajorUnitName: "rupee", MinorUnitName: "paisa"},
+	"NZD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "NZD", Name: "New Zealand Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 554, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"OMR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "OMR", Name: "Omani Rial", Fraction: 3, Grapheme: "\ufdfc", Template: "1 $", NumericCode: 512, MajorUnitName: "rial", MinorUnitName: "baisa"},
+	"PAB": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PAB", Name: "Panamanian Balboa", Fraction: 2, Grapheme: "B/.", Template: "$1", NumericCode: 590, MajorUnitName: "balboa", MinorUnitName: "centesimo"},
+	"PEN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PEN", Name: "Peruvian Sol", Fraction: 2, Grapheme: "S/", Template: "$1", NumericCode: 604, MajorUnitName: "sol", MinorUnitName: "centimo"},
+	"PHP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PHP", Name: "Philippine Peso", Fraction: 2, Grapheme: "\u20b1", Template: "$1", NumericCode: 608, MajorUnitName: "peso", MinorUnitName: "sentimo"},
+	"PKR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PKR", Name: "Pakistani Rupee", Fraction: 2, Grapheme: "\u20a8", Template: "$1", NumericCode: 586, MajorUnitName: "rupee", MinorUnitName: "paisa"},
+	"PLN": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PLN", Name: "Polish Zloty", Fraction: 2, Grapheme: "z\u0142", Template: "1 $", NumericCode: 985, MajorUnitName: "zloty", MinorUnitName: "grosz"},
+	"PYG": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "PYG", Name: "Paraguayan Guarani", Fraction: 0, Grapheme: "Gs", Template: "1$", NumericCode: 600, MajorUnitName: "guarani", MinorUnitName: "centimo"},
+	"QAR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "QAR", Name: "Qatari Rial", Fraction: 2, Grapheme: "\ufdfc", Template: "1 $", NumericCode: 634, MajorUnitName: "riyal", MinorUnitName: "dirham"},
+	"RON": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "RON", Name: "Romanian Leu", Fraction: 2, Grapheme: "lei", Template: "$1", NumericCode: 946, MajorUnitName: "leu", MinorUnitName: "ban"},
+	"RSD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "RSD", Name: "Serbian Dinar", Fraction: 2, Grapheme: "\u0414\u0438\u043d.", Template: "$1", NumericCode: 941, MajorUnitName: "dinar", MinorUnitName: "para"},
+	"RUB": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "RUB", Name: "Russian Ruble", Fraction: 2, Grapheme: "\u20bd", Template: "1 $", NumericCode: 643, MajorUnitName: "ruble", MinorUnitName: "kopek"},
+	"RUR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "RUR", Name: "Russian Ruble (1991-1998)", Fraction: 2, Grapheme: "\u20bd", Template: "1 $", NumericCode: 810, MajorUnitName: "ruble", MinorUnitName: "kopek"},
+	"SAR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SAR", Name: "Saudi Riyal", Fraction: 2, Grapheme: "\ufdfc", Template: "1 $", NumericCode: 682, MajorUnitName: "riyal", MinorUnitName: "halala"},
+	"SBD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SBD", Name: "Solomon Islands Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 90, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"SCR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SCR", Name: "Seychellois Rupee", Fraction: 2, Grapheme: "\u20a8", Template: "$1", NumericCode: 690, MajorUnitName: "rupee", MinorUnitName: "cent"},
+	"SEK": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SEK", Name: "Swedish Krona", Fraction: 2, Grapheme: "kr", Template: "1 $", NumericCode: 752, MajorUnitName: "krona", MinorUnitName: "ore"},
+	"SGD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SGD", Name: "Singapore Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 702, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"SHP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SHP", Name: "St. Helena Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", NumericCode: 654, MajorUnitName: "pound", MinorUnitName: "penny"},
+	"SOS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SOS", Name: "Somali Shilling", Fraction: 2, Grapheme: "S", Template: "$1", NumericCode: 706, MajorUnitName: "shilling", MinorUnitName: "senti"},
+	"SRD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SRD", Name: "Surinamese Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 968, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"SVC": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SVC", Name: "Salvadoran Colon", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 222, MajorUnitName: "colon", MinorUnitName: "centavo"},
+	"SYP": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "SYP", Name: "Syrian Pound", Fraction: 2, Grapheme: "\u00a3", Template: "$1", NumericCode: 760, MajorUnitName: "pound", MinorUnitName: "piastre"},
+	"THB": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "THB", Name: "Thai Baht", Fraction: 2, Grapheme: "\u0e3f", Template: "$1", NumericCode: 764, MajorUnitName: "baht", MinorUnitName: "satang"},
+	"TND": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TND", Name: "Tunisian Dinar", Fraction: 3, Grapheme: ".\u062f.\u062a", Template: "1 $", NumericCode: 788, MajorUnitName: "dinar", MinorUnitName: "millime"},
+	"TRL": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TRL", Name: "Turkish Lira (1922-2005)", Fraction: 2, Grapheme: "\u20a4", Template: "$1", NumericCode: 792, MajorUnitName: "lira", MinorUnitName: "kurus"},
+	"TRY": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TRY", Name: "Turkish Lira", Fraction: 2, Grapheme: "\u20ba", Template: "$1", NumericCode: 949, MajorUnitName: "lira", MinorUnitName: "kurus"},
+	"TTD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TTD", Name: "Trinidad and Tobago Dollar", Fraction: 2, Grapheme: "TT$", Template: "$1", NumericCode: 780, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"TWD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TWD", Name: "New Taiwan Dollar", Fraction: 0, Grapheme: "NT$", Template: "$1", NumericCode: 901, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"TZS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "TZS", Name: "Tanzanian Shilling", Fraction: 0, Grapheme: "TSh", Template: "$1", NumericCode: 834, MajorUnitName: "shilling", MinorUnitName: "senti"},
+	"UAH": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "UAH", Name: "Ukrainian Hryvnia", Fraction: 2, Grapheme: "\u20b4", Template: "$1", NumericCode: 980, MajorUnitName: "hryvnia", MinorUnitName: "kopiyka"},
+	"UGX": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "UGX", Name: "Ugandan Shilling", Fraction: 0, Grapheme: "USh", Template: "$1", NumericCode: 800, MajorUnitName: "shilling", MinorUnitName: "cent"},
+	"USD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "USD", Name: "US Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 840, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"UYU": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "UYU", Name: "Uruguayan Peso", Fraction: 0, Grapheme: "$U", Template: "$1", NumericCode: 858, MajorUnitName: "peso", MinorUnitName: "centesimo"},
+	"UZS": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "UZS", Name: "Uzbekistani Som", Fraction: 2, Grapheme: "so\u2019m", Template: "$1", NumericCode: 860, MajorUnitName: "som", MinorUnitName: "tiyin"},
+	"VEF": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "VEF", Name: "Venezuelan Bolivar", Fraction: 2, Grapheme: "Bs", Template: "$1", NumericCode: 937, MajorUnitName: "bolivar", MinorUnitName: "centimo"},
+	"VND": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "VND", Name: "Vietnamese Dong", Fraction: 0, Grapheme: "\u20ab", Template: "1 $", NumericCode: 704, MajorUnitName: "dong", MinorUnitName: "hao"},
+	"XCD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "XCD", Name: "East Caribbean Dollar", Fraction: 2, Grapheme: "$", Template: "$1", NumericCode: 951, MajorUnitName: "dollar", MinorUnitName: "cent"},
+	"YER": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "YER", Name: "Yemeni Rial", Fraction: 2, Grapheme: "\ufdfc", Template: "1 $", NumericCode: 886, MajorUnitName: "rial", MinorUnitName: "fils"},
+	"ZAR": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ZAR", Name: "South African Rand", Fraction: 2, Grapheme: "R", Template: "$1", NumericCode: 710, MajorUnitName: "rand", MinorUnitName: "cent"},
+	"ZMW": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ZMW", Name: "Zambian Kwacha", Fraction: 2, Grapheme: "ZK", Template: "$1", NumericCode: 967, MajorUnitName: "kwacha", MinorUnitName: "ngwee"},
+	"ZWD": {Type: FIAT, DecPoint: ".", Thousand: ",", Code: "ZWD", Name: "Zimbabwean Dollar", Fraction: 2, Grapheme: "Z$", Template: "$1", NumericCode: 716, MajorUnitName: "dollar", MinorUnitName: "cent"},
+
+	// Locale variants
+	// Same currency, formatted the way a particular locale expects. Note these don't
+	// use 3 char codes, so older releases can't read them back from MarshalBinary.
+	// Variants are left without a NumericCode, so looking up the ISO numeric code
+	// only ever finds the original.
+	"EUR-DE": {Type: FIAT, DecPoint: ",", Thousand: "\u00a0", Code: "EUR-DE", Name: "Euro", Fraction: 2, Grapheme: "\u20ac", Template: "1\u00a0$", MajorUnitName: "euro", MinorUnitName: "cent"},
 
 	// Cryptocurrencies
 	// Bitcoin has 2 accepted codes as of now. ISO 4217 standard is moving to XBT at some point
-	"BTC": {Type: CRYPTO, DecPoint: ".", Thousand: ",", Code: "BTC", Fraction: 8, Grapheme: "\u20bf", Template: "$1"},
-	"XBT": {Type: CRYPTO, DecPoint: ".", Thousand: ",", Code: "XBT", Fraction: 8, Grapheme: "\u20bf", Template: "$1"},
+	"BTC": {Type: CRYPTO, DecPoint: ".", Thousand: ",", Code: "BTC", Name: "Bitcoin", Fraction: 8, Grapheme: "\u20bf", Template: "$1"},
+	"XBT": {Type: CRYPTO, DecPoint: ".", Thousand: ",", Code: "XBT", Name: "Bitcoin", Fraction: 8, Grapheme: "\u20bf", Template: "$1"},
 
 	// Unknown currency.
 	// Only to be used in Test code.
@@ -203,6 +259,14 @@ func AddCurrency(Type CurrType, Code, Grapheme, Template, DecPoint, Thousand str
 	return currencies[Code]
 }
 
+// AddCurrencyFull lets you insert or update a currency in the currencies list
+// with all of its metadata, not just what AddCurrency takes.
+func AddCurrencyFull(c Currency) *Currency {
+	currencies[c.Code] = &c
+
+	return currencies[c.Code]
+}
+
 func newCurrency(code string) *Currency {
 	return &Currency{Code: strings.ToUpper(code)}
 }
@@ -213,6 +277,41 @@ func GetCurrency(code string) (*Currency, bool) {
 	return c, err
 }
 
+// MustGetCurrency returns the currency given the code, or panics if the code
+// has not been registered. Handy for package init and tests where the code is
+// a known constant.
+func MustGetCurrency(code string) *Currency {
+	c, ok := GetCurrency(code)
+	if !ok {
+		panic(fmt.Sprintf("Currency [%s] not supported", code))
+	}
+	return c
+}
+
+// GetCurrencyByNumeric returns the currency given its ISO 4217 numeric code.
+// Should more than one currency share the code, the one with the lowest
+// alphabetic code is returned so the result is stable.
+func GetCurrencyByNumeric(n int) (*Currency, bool) {
+	var found *Currency
+	if n == 0 {
+		return found, false
+	}
+
+	for _, c := range currencies {
+		if c.NumericCode == n && (found == nil || c.Code < found.Code) {
+			found = c
+		}
+	}
+
+	return found, found != nil
+}
+
+// HasCurrency returns whether the given code has been registered.
+func HasCurrency(code string) bool {
+	_, ok := GetCurrency(code)
+	return ok
+}
+
 // Formatter returns currency formatter representing
 // used currency structure
 func (c *Currency) Formatter() *Formatter {
@@ -222,6 +321,8 @@ func (c *Currency) Formatter() *Formatter {
 		Thousand: c.Thousand,
 		Grapheme: c.Grapheme,
 		Template: c.Template,
+		Grouping: c.Grouping,
+		Spacing:  c.Spacing,
 	}
 }
 
@@ -260,6 +361,36 @@ func (c *Currency) get() *Currency {
 	return c.getDefault()
 }
 
+// unitPlurals holds the unit names which don't just take an "s" in the plural.
+// Names already ending in "s" are left alone.
+var unitPlurals = map[string]string{
+	"baht":  "baht",
+	"chon":  "chon",
+	"jeon":  "jeon",
+	"kobo":  "kobo",
+	"krona": "kronor",
+	"krone": "kroner",
+	"leu":   "lei",
+	"lev":   "leva",
+	"penny": "pence",
+	"rand":  "rand",
+	"sen":   "sen",
+	"won":   "won",
+	"yen":   "yen",
+	"yuan":  "yuan",
+}
+
+// unitName returns the unit name pluralised to suit count, given as digits.
+func unitName(name, count string) string {
+	if count == "1" || strings.HasSuffix(name, "s") {
+		return name
+	}
+	if plural, ok := unitPlurals[name]; ok {
+		return plural
+	}
+	return name + "s"
+}
+
 func (c *Currency) equals(oc *Currency) bool {
 	return c.Code == oc.Code
 }
@@ -267,3 +398,21 @@ func (c *Currency) equals(oc *Currency) bool {
 func (c *Currency) String() string {
 	return c.Code
 }
+
+// Symbol returns the currency's Grapheme, ie. "$" for USD, or its Code if it
+// doesn't have one.
+func (c *Currency) Symbol() string {
+	if c.Grapheme == "" {
+		return c.Code
+	}
+	return c.Grapheme
+}
+
+// DisplayName returns the currency's Name, ie. "US Dollar", or its Code if it
+// doesn't have one.
+func (c *Currency) DisplayName() string {
+	if c.Name == "" {
+		return c.Code
+	}
+	return c.Name
+}
@@ -0,0 +1,137 @@
+// package money - Currency builder
+// AddCurrency takes a long list of positional strings and trusts all of them.
+// The builder names each field, and checks the result before registering it.
+
+package money
+
+import (
+	"strings"
+)
+
+// CurrencyBuilder builds up a Currency to register. Start with
+// NewCurrencyBuilder, chain the setters, and finish with Register:
+//
+//	c, err := NewCurrencyBuilder("GLD").
+//		Type(POINTS).
+//		Grapheme("G").
+//		Fraction(0).
+//		Register()
+type CurrencyBuilder struct {
+	c Currency
+}
+
+// NewCurrencyBuilder starts a CurrencyBuilder for code. Anything not set is
+// the same as AddCurrency's most common arguments: FIAT, "." and "," for the
+// separators, a template of "$1", 2 decimal places, and the code as grapheme.
+func NewCurrencyBuilder(code string) *CurrencyBuilder {
+	return &CurrencyBuilder{c: Currency{
+		Type:     FIAT,
+		Code:     code,
+		Fraction: 2,
+		Grapheme: code,
+		Template: "$1",
+		DecPoint: ".",
+		Thousand: ",",
+	}}
+}
+
+// Type sets the currency type.
+func (b *CurrencyBuilder) Type(t CurrType) *CurrencyBuilder {
+	b.c.Type = t
+	return b
+}
+
+// Name sets the English name, ie. "US Dollar".
+func (b *CurrencyBuilder) Name(name string) *CurrencyBuilder {
+	b.c.Name = name
+	return b
+}
+
+// Fraction sets the number of decimal places.
+func (b *CurrencyBuilder) Fraction(fraction int) *CurrencyBuilder {
+	b.c.Fraction = fraction
+	return b
+}
+
+// Grapheme sets the symbol, ie. "$".
+func (b *CurrencyBuilder) Grapheme(grapheme string) *CurrencyBuilder {
+	b.c.Grapheme = grapheme
+	return b
+}
+
+// Template sets where the amount ("1") and grapheme ("$") go, ie. "1 $".
+func (b *CurrencyBuilder) Template(template string) *CurrencyBuilder {
+	b.c.Template = template
+	return b
+}
+
+// DecPoint sets the decimal separator.
+func (b *CurrencyBuilder) DecPoint(decPoint string) *CurrencyBuilder {
+	b.c.DecPoint = decPoint
+	return b
+}
+
+// Thousand sets the thousands separator.
+func (b *CurrencyBuilder) Thousand(thousand string) *CurrencyBuilder {
+	b.c.Thousand = thousand
+	return b
+}
+
+// NumericCode sets the ISO 4217 numeric code.
+func (b *CurrencyBuilder) NumericCode(numericCode int) *CurrencyBuilder {
+	b.c.NumericCode = numericCode
+	return b
+}
+
+// UnitNames sets the singular names of the major and minor units, ie. "dollar"
+// and "cent".
+func (b *CurrencyBuilder) UnitNames(major, minor string) *CurrencyBuilder {
+	b.c.MajorUnitName = major
+	b.c.MinorUnitName = minor
+	return b
+}
+
+// CashInterval sets the cash rounding interval, as taken by RoundCashInterval.
+func (b *CurrencyBuilder) CashInterval(interval uint8) *CurrencyBuilder {
+	b.c.CashInterval = interval
+	return b
+}
+
+// Grouping sets how the digits are grouped, ie. GroupIndian.
+func (b *CurrencyBuilder) Grouping(style GroupingStyle) *CurrencyBuilder {
+	b.c.Grouping = style
+	return b
+}
+
+// Spacing sets the gap between the grapheme and the amount, ie. SpacingNone.
+func (b *CurrencyBuilder) Spacing(spacing SymbolSpacing) *CurrencyBuilder {
+	b.c.Spacing = spacing
+	return b
+}
+
+// Register checks the currency and, if it's valid, adds it to the currencies
+// list the same as AddCurrencyFull. An existing currency with the same code is
+// replaced.
+//
+// An error is returned, and nothing registered, if the code is empty, Fraction
+// is negative, the template has no "1" for the amount to go in, or the
+// CashInterval isn't supported.
+func (b *CurrencyBuilder) Register() (*Currency, error) {
+
+	c := b.c
+
+	if strings.TrimSpace(c.Code) == "" {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot register currency: code is empty")
+	}
+	if c.Fraction < 0 {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot register currency [%s]: fraction [%d] is negative", c.Code, c.Fraction)
+	}
+	if !strings.Contains(c.Template, "1") {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot register currency [%s]: template '%s' has no amount placeholder '1'", c.Code, c.Template)
+	}
+	if c.CashInterval != 0 && !validCashInterval(c.CashInterval) {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot register currency [%s]: cash rounding interval [%d] not supported", c.Code, c.CashInterval)
+	}
+
+	return AddCurrencyFull(c), nil
+}
@@ -0,0 +1,76 @@
+package money
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCurrencyBuilder_Register(t *testing.T) {
+	defer delete(currencies, "#10")
+
+	c, err := NewCurrencyBuilder("#10").
+		Type(POINTS).
+		Name("Hash Ten").
+		Grapheme("#").
+		Template("1 $").
+		Fraction(3).
+		DecPoint(",").
+		Thousand(".").
+		NumericCode(9010).
+		UnitNames("hash", "bit").
+		CashInterval(5).
+		Register()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	desired := &Currency{Type: POINTS, Code: "#10", Name: "Hash Ten", Fraction: 3, Grapheme: "#", Template: "1 $", DecPoint: ",", Thousand: ".", NumericCode: 9010, MajorUnitName: "hash", MinorUnitName: "bit", CashInterval: 5}
+	if !reflect.DeepEqual(c, desired) {
+		t.Errorf("Currencies do not match %+v got %+v", desired, c)
+	}
+	if got := MustGetCurrency("#10"); got != c {
+		t.Errorf("Expected %+v to be registered, got %+v", c, got)
+	}
+	if got := RequireFromString("#10", "1234.5").FormattedStringBank(); got != "1.234,500 #" {
+		t.Errorf("Expected 1.234,500 # got %s", got)
+	}
+}
+
+func TestCurrencyBuilder_Defaults(t *testing.T) {
+	defer delete(currencies, "#11")
+
+	c, err := NewCurrencyBuilder("#11").Register()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	desired := &Currency{Type: FIAT, Code: "#11", Fraction: 2, Grapheme: "#11", Template: "$1", DecPoint: ".", Thousand: ","}
+	if !reflect.DeepEqual(c, desired) {
+		t.Errorf("Currencies do not match %+v got %+v", desired, c)
+	}
+}
+
+func TestCurrencyBuilder_Invalid(t *testing.T) {
+	tcs := []struct {
+		name    string
+		builder *CurrencyBuilder
+	}{
+		{"empty code", NewCurrencyBuilder("")},
+		{"blank code", NewCurrencyBuilder("  ")},
+		{"negative fraction", NewCurrencyBuilder("#12").Fraction(-1)},
+		{"no placeholder", NewCurrencyBuilder("#12").Template("$")},
+		{"empty template", NewCurrencyBuilder("#12").Template("")},
+		{"bad cash interval", NewCurrencyBuilder("#12").CashInterval(3)},
+	}
+
+	for _, tc := range tcs {
+		c, err := tc.builder.Register()
+		if err == nil || c != nil {
+			t.Errorf("%s: expected an error, got %+v", tc.name, c)
+		}
+	}
+
+	if HasCurrency("#12") {
+		t.Errorf("Expected nothing to be registered")
+	}
+}
@@ -86,3 +86,126 @@ func TestCurrency_GetNonExistingCurrency(t *testing.T) {
 		t.Errorf("Unexpected currency returned %+v", currency)
 	}
 }
+
+func TestCurrency_MustGetCurrency(t *testing.T) {
+	c := MustGetCurrency("USD")
+	if c.Code != "USD" {
+		t.Errorf("Expected USD got %s", c.Code)
+	}
+
+	var msg interface{}
+	func() {
+		defer func() {
+			msg = recover()
+		}()
+		MustGetCurrency("I*am*Not*a*Currency")
+	}()
+
+	expected := "Currency [I*am*Not*a*Currency] not supported"
+	if msg != expected {
+		t.Errorf("Expected panic %q got %v", expected, msg)
+	}
+}
+
+func TestCurrency_HasCurrency(t *testing.T) {
+	tcs := []struct {
+		code     string
+		expected bool
+	}{
+		{"USD", true},
+		{"BTC", true},
+		{"I*am*Not*a*Currency", false},
+	}
+
+	for _, tc := range tcs {
+		if HasCurrency(tc.code) != tc.expected {
+			t.Errorf("Expected HasCurrency(%s) to be %v", tc.code, tc.expected)
+		}
+	}
+}
+
+func TestCurrency_GetCurrencyByNumeric(t *testing.T) {
+	tcs := []struct {
+		numeric  int
+		code     string
+		minor    string
+		expected bool
+	}{
+		{840, "USD", "cent", true},
+		{978, "EUR", "cent", true},
+		{826, "GBP", "penny", true},
+		{392, "JPY", "sen", true},
+		{0, "", "", false},
+		{999999, "", "", false},
+	}
+
+	for _, tc := range tcs {
+		c, ok := GetCurrencyByNumeric(tc.numeric)
+		if ok != tc.expected {
+			t.Errorf("Expected lookup of %d to return %v got %v", tc.numeric, tc.expected, ok)
+			continue
+		}
+		if !ok {
+			if c != nil {
+				t.Errorf("Unexpected currency returned %+v", c)
+			}
+			continue
+		}
+		if c.Code != tc.code || c.MinorUnitName != tc.minor {
+			t.Errorf("Expected %d to be %s (%s) got %s (%s)", tc.numeric, tc.code, tc.minor, c.Code, c.MinorUnitName)
+		}
+	}
+
+	// Locale variants don't share the ISO code
+	if c := MustGetCurrency("EUR-DE"); c.NumericCode != 0 {
+		t.Errorf("Expected EUR-DE to have no numeric code, got %d", c.NumericCode)
+	}
+
+	// Crypto doesn't have a numeric code
+	if c := MustGetCurrency("BTC"); c.NumericCode != 0 || c.MinorUnitName != "" {
+		t.Errorf("Expected BTC to have no numeric code or minor unit, got %+v", c)
+	}
+}
+
+func TestCurrency_AddCurrencyFull(t *testing.T) {
+	desired := Currency{Type: FIAT, DecPoint: ".", Thousand: ",", Code: "#03", Name: "Hash Three", Fraction: 2, Grapheme: "#", Template: "$1", NumericCode: 9003, MinorUnitName: "bit", CashInterval: 10}
+	added := AddCurrencyFull(desired)
+	if !reflect.DeepEqual(added, &desired) {
+		t.Errorf("Currencies do not match %+v got %+v", desired, added)
+	}
+
+	currency, ok := GetCurrencyByNumeric(9003)
+	if !ok || currency != added {
+		t.Errorf("Expected to find %+v by numeric code, got %+v", added, currency)
+	}
+}
+
+func TestCurrency_SymbolAndDisplayName(t *testing.T) {
+	AddCurrencyFull(Currency{Type: POINTS, Code: "#04", Fraction: 0})
+	AddCurrencyFull(Currency{Type: POINTS, Code: "#05", Name: "Hash Five", Grapheme: "#", Fraction: 0})
+	defer delete(currencies, "#04")
+	defer delete(currencies, "#05")
+
+	tcs := []struct {
+		code   string
+		name   string
+		symbol string
+	}{
+		{"USD", "US Dollar", "$"},
+		{"EUR", "Euro", "\u20ac"},
+		{"CHF", "Swiss Franc", "CHF"},
+		{"BTC", "Bitcoin", "\u20bf"},
+		{"#05", "Hash Five", "#"},
+		{"#04", "#04", "#04"},
+	}
+
+	for _, tc := range tcs {
+		c := MustGetCurrency(tc.code)
+		if c.DisplayName() != tc.name {
+			t.Errorf("Expected %s DisplayName %q got %q", tc.code, tc.name, c.DisplayName())
+		}
+		if c.Symbol() != tc.symbol {
+			t.Errorf("Expected %s Symbol %q got %q", tc.code, tc.symbol, c.Symbol())
+		}
+	}
+}
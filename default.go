@@ -0,0 +1,56 @@
+// package money - Default currency
+// Plenty of apps only ever deal in one currency. Setting it once saves passing
+// the same code to every constructor.
+
+package money
+
+import (
+	"sync"
+)
+
+var (
+	defaultMu   sync.RWMutex
+	defaultCode = UnknownCurrencyCode
+)
+
+// SetDefaultCurrency sets the currency used by NewDefault and ZeroDefault. It's
+// safe to call at any time, from any goroutine, though it's usually done once
+// at startup. Until it's called the default is UnknownCurrencyCode.
+//
+// An error is returned, and the default left as it was, if code isn't a known
+// currency.
+func SetDefaultCurrency(code string) error {
+	if _, ok := GetCurrency(code); !ok {
+		return &UnsupportedCurrencyError{Code: code}
+	}
+
+	defaultMu.Lock()
+	defaultCode = code
+	defaultMu.Unlock()
+
+	return nil
+}
+
+// DefaultCurrency returns the code set by SetDefaultCurrency.
+func DefaultCurrency() string {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+
+	return defaultCode
+}
+
+// NewDefault is NewFromString in the default currency.
+//
+// Example:
+//
+//	money.SetDefaultCurrency("EUR")
+//	m, _ := money.NewDefault("12.50") // EUR 12.50
+func NewDefault(value string) (Money, error) {
+	return NewFromString(DefaultCurrency(), value)
+}
+
+// ZeroDefault returns zero in the default currency.
+func ZeroDefault() Money {
+	m, _ := New(DefaultCurrency(), 0, 0)
+	return m
+}
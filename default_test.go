@@ -0,0 +1,60 @@
+package money
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestDefaultCurrency(t *testing.T) {
+	defer SetDefaultCurrency(UnknownCurrencyCode)
+
+	if code := DefaultCurrency(); code != UnknownCurrencyCode {
+		t.Errorf("expected %s before it's set, got %s", UnknownCurrencyCode, code)
+	}
+
+	if err := SetDefaultCurrency("EUR"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	m, err := NewDefault("12.50")
+	if err != nil || m.String() != "12.5" || m.currency.Code != "EUR" {
+		t.Errorf("expected EUR 12.5, got %s %s (%v)", m.currency, m, err)
+	}
+
+	if z := ZeroDefault(); z.String() != "0" || z.currency.Code != "EUR" {
+		t.Errorf("expected EUR 0, got %s %s", z.currency, z)
+	}
+
+	if _, err := NewDefault("twelve"); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse, got %v", err)
+	}
+
+	// A bad code is rejected, and leaves the default alone
+	if err := SetDefaultCurrency("XXXX"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+	if code := DefaultCurrency(); code != "EUR" {
+		t.Errorf("expected EUR to still be the default, got %s", code)
+	}
+}
+
+func TestDefaultCurrency_Concurrent(t *testing.T) {
+	defer SetDefaultCurrency(UnknownCurrencyCode)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(code string) {
+			defer wg.Done()
+			SetDefaultCurrency(code)
+		}([]string{"USD", "EUR"}[i%2])
+		go func() {
+			defer wg.Done()
+			if m := ZeroDefault(); m.currency.Code != "USD" && m.currency.Code != "EUR" && m.currency.Code != UnknownCurrencyCode {
+				t.Errorf("unexpected default %s", m.currency)
+			}
+		}()
+	}
+	wg.Wait()
+}
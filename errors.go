@@ -0,0 +1,71 @@
+// package money - Errors
+// Everything the package returns can be matched with errors.Is against one of
+// the sentinels below, while the message stays as readable as it always was.
+
+package money
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors, for use with errors.Is.
+var (
+	// ErrUnsupportedCurrency means a currency code wasn't in the registry. Use
+	// errors.As with an *UnsupportedCurrencyError to find out which one.
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+	// ErrCurrencyMismatch means two Moneys of different currencies were mixed.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+
+	// ErrParse means an amount, or an encoded Money, couldn't be read.
+	ErrParse = errors.New("cannot parse money")
+
+	// ErrInvalidArgument means an operation can't be done with the arguments
+	// it was given, ie. a zero divisor or an unsupported rounding interval.
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// UnsupportedCurrencyError is returned when a currency code isn't registered.
+type UnsupportedCurrencyError struct {
+	Code string
+}
+
+func (e *UnsupportedCurrencyError) Error() string {
+	return fmt.Sprintf("Currency [%s] not supported", e.Code)
+}
+
+// Is makes errors.Is(err, ErrUnsupportedCurrency) true.
+func (e *UnsupportedCurrencyError) Is(target error) bool {
+	return target == ErrUnsupportedCurrency
+}
+
+// moneyError is an error of one of the sentinel kinds, with its own message,
+// and optionally the error that caused it.
+type moneyError struct {
+	kind  error
+	msg   string
+	cause error
+}
+
+// newError returns an error of the given kind, formatted the same as
+// fmt.Errorf, wrapping cause (which may be nil).
+func newError(kind, cause error, format string, a ...interface{}) error {
+	return &moneyError{
+		kind:  kind,
+		msg:   fmt.Sprintf(format, a...),
+		cause: cause,
+	}
+}
+
+func (e *moneyError) Error() string {
+	return e.msg
+}
+
+func (e *moneyError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *moneyError) Unwrap() error {
+	return e.cause
+}
@@ -0,0 +1,80 @@
+package money
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/shopspring/decimal"
+	"testing"
+)
+
+func TestErrors_UnsupportedCurrency(t *testing.T) {
+	_, err := New("I*am*Not*a*Currency", 123, -2)
+	if !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+	if err.Error() != "Currency [I*am*Not*a*Currency] not supported" {
+		t.Errorf("unexpected message %q", err)
+	}
+
+	var uce *UnsupportedCurrencyError
+	if !errors.As(err, &uce) || uce.Code != "I*am*Not*a*Currency" {
+		t.Errorf("expected an UnsupportedCurrencyError for the code, got %v", err)
+	}
+
+	if errors.Is(err, ErrParse) || errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected only ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestErrors_Kinds(t *testing.T) {
+	var m Money
+	notUnknown := RequireFromString("USD", "1")
+
+	_, percentErr := RequireFromString("USD", "1").PercentChange(RequireFromString("EUR", "1"))
+	_, formatErr := NewFormatter(2, ".", ",", "$", "$1").Parse("$1.2x")
+
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"NewFromString", func() error { _, err := NewFromString("USD", "abc"); return err }(), ErrParse},
+		{"NewFromStringStrict", func() error { _, err := NewFromStringStrict("USD", "1.005"); return err }(), ErrParse},
+		{"NewFromBigInt", func() error { _, err := NewFromBigInt("XXXX", nil, 0); return err }(), ErrUnsupportedCurrency},
+		{"UpdateCurrency", notUnknown.UpdateCurrency("EUR"), ErrCurrencyMismatch},
+		{"PercentChange", percentErr, ErrCurrencyMismatch},
+		{"Formatter.Parse", formatErr, ErrParse},
+		{"UnmarshalJSON", json.Unmarshal([]byte(`{"amount":"abc","currency":"USD"}`), &m), ErrParse},
+		{"UnmarshalJSON currency", json.Unmarshal([]byte(`{"amount":"1","currency":"XXXX"}`), &m), ErrUnsupportedCurrency},
+		{"UnmarshalText", m.UnmarshalText([]byte("abc")), ErrParse},
+		{"UnmarshalBinary", m.UnmarshalBinary([]byte("USD")), ErrParse},
+		{"RoundCashInterval", func() error { _, err := notUnknown.RoundCashInterval(7); return err }(), ErrInvalidArgument},
+		{"PercentChange zero", func() error { _, err := notUnknown.PercentChange(RequireFromString("USD", "0")); return err }(), ErrInvalidArgument},
+		{"SplitByPercent", func() error { _, err := notUnknown.SplitByPercent(decimal.New(50, 0)); return err }(), ErrInvalidArgument},
+		{"RoundToNearest", func() error { _, err := notUnknown.RoundToNearest(RequireFromString("USD", "0")); return err }(), ErrInvalidArgument},
+		{"SnapToEnding", func() error { _, err := notUnknown.SnapToEnding(100); return err }(), ErrInvalidArgument},
+		{"Installments", func() error { _, err := notUnknown.Installments(0, false); return err }(), ErrInvalidArgument},
+		{"NewBreakdown", func() error { _, err := NewBreakdown(notUnknown, decimal.New(-1, 0), decimal.Zero); return err }(), ErrInvalidArgument},
+		{"DivMod", func() error { _, _, err := notUnknown.DivMod(RequireFromString("USD", "0")); return err }(), ErrInvalidArgument},
+		{"DivMod too big", func() error { _, _, err := RequireFromString("USD", "1e30").DivMod(notUnknown); return err }(), ErrInvalidArgument},
+		{"CurrencyBuilder.Register", func() error { _, err := NewCurrencyBuilder("").Register(); return err }(), ErrInvalidArgument},
+		{"MinValid", func() error { _, err := MinValid(); return err }(), ErrInvalidArgument},
+		{"Sqrt", func() error { _, err := RequireFromString("USD", "-1").Sqrt(); return err }(), ErrInvalidArgument},
+		{"Bucketize", func() error { _, err := Bucketize(nil, nil); return err }(), ErrInvalidArgument},
+		{"Bucketize order", func() error { _, err := Bucketize(nil, []Money{notUnknown, notUnknown}); return err }(), ErrInvalidArgument},
+		{"ConvertAndSplit", func() error {
+			_, err := NewExchanger(nil, "USD").ConvertAndSplit(context.Background(), notUnknown, "USD", 0)
+			return err
+		}(), ErrInvalidArgument},
+		{"EnsureCurrencyScale", func() error { _, err := RequireFromString("USD", "1.005").EnsureCurrencyScale(); return err }(), ErrInvalidArgument},
+	}
+
+	for _, test := range tests {
+		if test.err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		} else if !errors.Is(test.err, test.kind) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.kind, test.err)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+// package money - Currency exchange
+// The exchange library promised in the Money docs. Moneys still never mix
+// currencies on their own, but an Exchanger can turn one currency into another
+// using rates from a RateProvider.
+
+package money
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoRate is returned (wrapped) by a RateProvider when it doesn't have a rate
+// for the requested pair, as opposed to failing to look it up.
+var ErrNoRate = errors.New("no exchange rate available")
+
+// RateProvider looks up exchange rates. Rate returns how many units of to one
+// unit of from is worth, ie. Rate(ctx, "USD", "EUR") might return 0.92.
+//
+// Providers will often make network calls, so they should give up when ctx is
+// done. If there's simply no rate for the pair, the error should wrap ErrNoRate
+// so the Exchanger knows it can try another route.
+type RateProvider interface {
+	Rate(ctx context.Context, from, to string) (decimal.Decimal, error)
+}
+
+// StaticRateProvider is a RateProvider with a fixed table of rates. It never
+// blocks, so it ignores the context. Safe for concurrent use.
+type StaticRateProvider struct {
+	mu    sync.RWMutex
+	rates map[string]decimal.Decimal
+}
+
+// NewStaticRateProvider creates an empty StaticRateProvider. Use SetRate to
+// fill it in.
+func NewStaticRateProvider() *StaticRateProvider {
+	return &StaticRateProvider{rates: map[string]decimal.Decimal{}}
+}
+
+// SetRate sets the rate to convert one unit of from into to. Only that
+// direction is set; add the reverse separately if you need it, as real world
+// buy and sell rates rarely invert exactly.
+func (p *StaticRateProvider) SetRate(from, to string, rate decimal.Decimal) *StaticRateProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.rates[rateKey(from, to)] = rate
+
+	return p
+}
+
+// Rate implements the RateProvider interface.
+func (p *StaticRateProvider) Rate(_ context.Context, from, to string) (decimal.Decimal, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	rate, ok := p.rates[rateKey(from, to)]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("Cannot convert [%s] to [%s]: %w", from, to, ErrNoRate)
+	}
+
+	return rate, nil
+}
+
+// LoadRates reads a StaticRateProvider from a JSON object of rates, keyed by
+// currency pair, ie.
+//
+//	{"USD/EUR":"0.92","EUR/USD":"1.087"}
+//
+// Each key sets the rate from the first currency into the second, as SetRate
+// does. Rates are read straight into decimals, never via a float, and can be
+// either strings or bare JSON numbers.
+//
+// An error is returned if the JSON can't be read, a key isn't a pair of known
+// currency codes, or a rate isn't a positive number.
+func LoadRates(r io.Reader) (*StaticRateProvider, error) {
+
+	var table map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&table); err != nil {
+		return nil, newError(ErrParse, err, "Cannot read rates: %s", err)
+	}
+
+	// Sorted, so the same bad file always gives the same error
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	p := NewStaticRateProvider()
+	for _, key := range keys {
+		codes := strings.Split(key, "/")
+		if len(codes) != 2 || codes[0] == "" || codes[1] == "" {
+			return nil, newError(ErrParse, nil, "Cannot read rate [%s], expected a pair like USD/EUR", key)
+		}
+		for _, code := range codes {
+			if _, ok := GetCurrency(code); !ok {
+				err := &UnsupportedCurrencyError{Code: code}
+				return nil, newError(ErrParse, err, "Cannot read rate [%s]: %s", key, err)
+			}
+		}
+
+		str, _ := unquoteIfQuoted([]byte(table[key]))
+		rate, err := decimal.NewFromString(str)
+		if err != nil {
+			return nil, newError(ErrParse, err, "Cannot read rate [%s] '%s': %s", key, table[key], err)
+		}
+		if rate.Sign() <= 0 {
+			return nil, newError(ErrParse, nil, "Cannot read rate [%s] '%s', it must be positive", key, table[key])
+		}
+
+		p.SetRate(codes[0], codes[1], rate)
+	}
+
+	return p, nil
+}
+
+// CachingRateProvider wraps another RateProvider, remembering each rate it
+// looks up for TTL so repeated conversions don't hammer the provider. Safe for
+// concurrent use; concurrent misses on the same pair share a single lookup.
+// The zero value is ready to use once Inner is set.
+//
+// If ServeStale is set and the wrapped provider fails once a rate has expired,
+// the last known rate is returned instead of the error.
+type CachingRateProvider struct {
+	Inner      RateProvider
+	TTL        time.Duration
+	ServeStale bool
+
+	mu       sync.Mutex
+	cache    map[string]cachedRate
+	inflight map[string]*rateCall
+	now      func() time.Time
+}
+
+type cachedRate struct {
+	rate    decimal.Decimal
+	expires time.Time
+}
+
+// rateCall is a lookup in progress, done is closed once rate and err are set.
+type rateCall struct {
+	done chan struct{}
+	rate decimal.Decimal
+	err  error
+}
+
+// NewCachingRateProvider creates a CachingRateProvider around inner, keeping
+// rates for ttl.
+func NewCachingRateProvider(inner RateProvider, ttl time.Duration) *CachingRateProvider {
+	return &CachingRateProvider{
+		Inner: inner,
+		TTL:   ttl,
+	}
+}
+
+// clock returns the current time, or the overridden time in tests.
+func (p *CachingRateProvider) clock() time.Time {
+	if p.now == nil {
+		return time.Now()
+	}
+	return p.now()
+}
+
+// Rate implements the RateProvider interface.
+func (p *CachingRateProvider) Rate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+
+	key := rateKey(from, to)
+
+	for {
+		p.mu.Lock()
+		cached, ok := p.cache[key]
+		if ok && p.clock().Before(cached.expires) {
+			p.mu.Unlock()
+			return cached.rate, nil
+		}
+
+		// Only one lookup per pair at a time, anyone else who misses waits for it
+		call, waiting := p.inflight[key]
+		if !waiting {
+			if p.inflight == nil {
+				p.inflight = map[string]*rateCall{}
+			}
+			call = &rateCall{done: make(chan struct{})}
+			p.inflight[key] = call
+		}
+		p.mu.Unlock()
+
+		if waiting {
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return decimal.Zero, ctx.Err()
+			}
+
+			// The lookup ran on the first caller's context, if that was cancelled
+			// it says nothing about ours, so go round again and do it ourselves
+			if ctx.Err() == nil && (errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded)) {
+				continue
+			}
+		} else {
+			// Don't hold the lock while we wait on the provider, it could be a while
+			call.rate, call.err = p.Inner.Rate(ctx, from, to)
+
+			p.mu.Lock()
+			if call.err == nil {
+				if p.cache == nil {
+					p.cache = map[string]cachedRate{}
+				}
+				p.cache[key] = cachedRate{rate: call.rate, expires: p.clock().Add(p.TTL)}
+			}
+			delete(p.inflight, key)
+			p.mu.Unlock()
+
+			close(call.done)
+		}
+
+		if call.err != nil {
+			if ok && p.ServeStale {
+				return cached.rate, nil
+			}
+			return decimal.Zero, call.err
+		}
+
+		return call.rate, nil
+	}
+}
+
+// Invalidate forgets every cached rate, stale ones included.
+func (p *CachingRateProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cache = map[string]cachedRate{}
+}
+
+// Exchanger converts Moneys between currencies using the rates from Provider.
+//
+// When there's no direct rate between two currencies, and Base is set, the
+// Exchanger triangulates through Base, ie. EUR -> USD -> JPY.
+type Exchanger struct {
+	Provider RateProvider
+	Base     string
+}
+
+// NewExchanger creates an Exchanger using the given provider. Pass an empty base
+// to turn off triangulation.
+func NewExchanger(provider RateProvider, base string) *Exchanger {
+	return &Exchanger{
+		Provider: provider,
+		Base:     base,
+	}
+}
+
+// Convert returns m converted into the to currency.
+//
+// The result isn't rounded, as the rate usually carries more precision than the
+// currency does. Round it (ie. with Round or RoundBank) when you're done with it.
+//
+// An error is returned if to isn't a known currency, no rate can be found, or
+// ctx is done.
+func (e *Exchanger) Convert(ctx context.Context, m Money, to string) (Money, error) {
+
+	m.ensureInitialized()
+
+	c, ok := GetCurrency(to)
+	if !ok {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: to}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, err
+	}
+
+	rate, err := e.Rate(ctx, m.currency.Code, to)
+	if err != nil {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, err
+	}
+
+	return Money{
+		amount:   m.amount.Mul(rate),
+		currency: c,
+	}, nil
+}
+
+// ConvertAll converts each of ms into the to currency, as Convert does, but only
+// looks up one rate per distinct source currency, rather than one per Money.
+//
+// It stops at the first failure, returning an error which names the index of
+// the Money that couldn't be converted.
+func (e *Exchanger) ConvertAll(ctx context.Context, ms []Money, to string) ([]Money, error) {
+
+	c, ok := GetCurrency(to)
+	if !ok {
+		return nil, &UnsupportedCurrencyError{Code: to}
+	}
+
+	rates := map[string]decimal.Decimal{}
+	out := make([]Money, len(ms))
+
+	for i, m := range ms {
+		m.ensureInitialized()
+
+		rate, ok := rates[m.currency.Code]
+		if !ok {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("Cannot convert item [%d]: %w", i, err)
+			}
+
+			var err error
+			if rate, err = e.Rate(ctx, m.currency.Code, to); err != nil {
+				return nil, fmt.Errorf("Cannot convert item [%d]: %w", i, err)
+			}
+			rates[m.currency.Code] = rate
+		}
+
+		out[i] = Money{
+			amount:   m.amount.Mul(rate),
+			currency: c,
+		}
+	}
+
+	return out, nil
+}
+
+// ConvertAndSplit converts m into the to currency, as Convert does, and splits
+// the result into n equal parts. The converted amount is rounded to the to
+// currency's Fraction once, using DefaultRoundingMode, and any minor units left
+// over are handed out as Allocate does, so the parts always add up to exactly
+// that rounded amount.
+//
+// Example:
+//
+//	e.ConvertAndSplit(ctx, RequireFromString("EUR", "100"), "USD", 3) // 36.24, 36.23, 36.23 at 1.087
+//
+// An error is returned if n is less than one, or as Convert does.
+func (e *Exchanger) ConvertAndSplit(ctx context.Context, m Money, to string, n int) ([]Money, error) {
+
+	if n < 1 {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot split into [%d] parts", n)
+	}
+
+	converted, err := e.Convert(ctx, m, to)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make([]decimal.Decimal, n)
+	for i := range weights {
+		weights[i] = decimal.New(1, 0)
+	}
+
+	return converted.allocate(weights, decimal.New(int64(n), 0), RemainderEarliest), nil
+}
+
+// Rate returns the rate to convert one unit of from into to, triangulating
+// through Base if there's no direct rate.
+func (e *Exchanger) Rate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+
+	if from == to {
+		return decimal.New(1, 0), nil
+	}
+
+	rate, err := e.Provider.Rate(ctx, from, to)
+	if err == nil || !errors.Is(err, ErrNoRate) || e.Base == "" || from == e.Base || to == e.Base {
+		return rate, err
+	}
+
+	// No direct rate, so go via the base currency
+	toBase, err := e.Provider.Rate(ctx, from, e.Base)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	fromBase, err := e.Provider.Rate(ctx, e.Base, to)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return toBase.Mul(fromBase), nil
+}
+
+// rateKey is the key for a currency pair, ie. "USD/EUR"
+func rateKey(from, to string) string {
+	return from + "/" + to
+}
@@ -0,0 +1,527 @@
+package money
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRateProvider() *StaticRateProvider {
+	return NewStaticRateProvider().
+		SetRate("USD", "EUR", decimal.RequireFromString("0.92")).
+		SetRate("EUR", "USD", decimal.RequireFromString("1.087")).
+		SetRate("USD", "JPY", decimal.RequireFromString("150.25"))
+}
+
+func TestExchanger_Convert(t *testing.T) {
+	tests := []struct {
+		from     string
+		amount   string
+		to       string
+		expected string
+	}{
+		{"USD", "100", "EUR", "92"},
+		{"EUR", "100", "USD", "108.7"},
+		{"USD", "1.50", "JPY", "225.375"},
+		{"USD", "-10", "EUR", "-9.2"},
+		{"USD", "12.34", "USD", "12.34"},
+	}
+
+	e := NewExchanger(testRateProvider(), "")
+
+	for _, test := range tests {
+		got, err := e.Convert(context.Background(), RequireFromString(test.from, test.amount), test.to)
+		if err != nil {
+			t.Errorf("%s %s -> %s: unexpected error %s", test.from, test.amount, test.to, err)
+		} else if got.currency.Code != test.to || got.String() != test.expected {
+			t.Errorf("%s %s -> %s: expected %s got %s %s", test.from, test.amount, test.to, test.expected, got.currency, got)
+		}
+	}
+}
+
+func TestExchanger_ConvertAndSplit(t *testing.T) {
+	tests := []struct {
+		from     string
+		amount   string
+		to       string
+		n        int
+		expected []string
+	}{
+		{"EUR", "100", "USD", 3, []string{"36.24", "36.23", "36.23"}},
+		{"EUR", "0.05", "USD", 3, []string{"0.02", "0.02", "0.01"}},
+		{"EUR", "-100", "USD", 3, []string{"-36.24", "-36.23", "-36.23"}},
+		{"USD", "1.50", "JPY", 2, []string{"113", "112"}},
+		{"USD", "12.34", "USD", 1, []string{"12.34"}},
+	}
+
+	e := NewExchanger(testRateProvider(), "")
+
+	for _, test := range tests {
+		parts, err := e.ConvertAndSplit(context.Background(), RequireFromString(test.from, test.amount), test.to, test.n)
+		if err != nil {
+			t.Errorf("%s %s -> %s: unexpected error %s", test.from, test.amount, test.to, err)
+			continue
+		}
+
+		converted, _ := e.Convert(context.Background(), RequireFromString(test.from, test.amount), test.to)
+		sum := Money{amount: decimal.Zero, currency: converted.currency}
+		got := make([]string, len(parts))
+		for i, p := range parts {
+			if p.currency.Code != test.to {
+				t.Errorf("%s %s -> %s: expected a part in %s got %s", test.from, test.amount, test.to, test.to, p.currency)
+			}
+			sum = sum.Add(p)
+			got[i] = p.String()
+		}
+		if !sum.Equals(converted.RoundBank(int32(converted.currency.Fraction))) {
+			t.Errorf("%s %s -> %s: expected the parts to add up to %s got %s", test.from, test.amount, test.to, converted, sum)
+		}
+
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s %s -> %s: expected %v got %v", test.from, test.amount, test.to, test.expected, got)
+		}
+	}
+}
+
+func TestExchanger_ConvertAndSplitErrors(t *testing.T) {
+	e := NewExchanger(testRateProvider(), "")
+	m := RequireFromString("EUR", "100")
+
+	if _, err := e.ConvertAndSplit(context.Background(), m, "USD", 0); err == nil {
+		t.Error("expected an error for zero parts")
+	}
+	if _, err := e.ConvertAndSplit(context.Background(), m, "JPY", 3); !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected ErrNoRate, got %v", err)
+	}
+	if _, err := e.ConvertAndSplit(context.Background(), m, "I*am*Not*a*Currency", 3); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestLoadRates(t *testing.T) {
+	rates := `{
+		"USD/EUR": "0.92",
+		"EUR/USD": "1.087",
+		"USD/JPY": 150.25,
+		"GBP/USD": "1.2345678901234567890123"
+	}`
+
+	p, err := LoadRates(strings.NewReader(rates))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	e := NewExchanger(p, "")
+	tests := []struct {
+		from     string
+		amount   string
+		to       string
+		expected string
+	}{
+		{"USD", "100", "EUR", "92"},
+		{"EUR", "100", "USD", "108.7"},
+		{"USD", "1.50", "JPY", "225.375"},
+		{"GBP", "10", "USD", "12.345678901234567890123"},
+	}
+
+	for _, test := range tests {
+		got, err := e.Convert(context.Background(), RequireFromString(test.from, test.amount), test.to)
+		if err != nil {
+			t.Errorf("%s %s -> %s: unexpected error %s", test.from, test.amount, test.to, err)
+		} else if got.currency.Code != test.to || got.String() != test.expected {
+			t.Errorf("%s %s -> %s: expected %s got %s %s", test.from, test.amount, test.to, test.expected, got.currency, got)
+		}
+	}
+
+	// Only the directions given are loaded
+	if _, err := p.Rate(context.Background(), "JPY", "USD"); !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected ErrNoRate, got %v", err)
+	}
+
+	for _, bad := range []string{
+		``,
+		`[]`,
+		`{"USD/EUR":"0.92"`,
+		`{"USDEUR":"0.92"}`,
+		`{"USD/":"0.92"}`,
+		`{"USD/EUR/JPY":"0.92"}`,
+		`{"USD/EUR":"abc"}`,
+		`{"USD/EUR":""}`,
+		`{"USD/EUR":true}`,
+		`{"USD/EUR":"0"}`,
+		`{"USD/EUR":"-0.92"}`,
+	} {
+		if _, err := LoadRates(strings.NewReader(bad)); !errors.Is(err, ErrParse) {
+			t.Errorf("%s: expected ErrParse, got %v", bad, err)
+		}
+	}
+
+	_, err = LoadRates(strings.NewReader(`{"USD/XXXX":"1"}`))
+	if !errors.Is(err, ErrParse) || !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrParse and ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestExchanger_Triangulate(t *testing.T) {
+	m := RequireFromString("EUR", "100")
+
+	// No direct EUR/JPY rate, and no base to go through
+	_, err := NewExchanger(testRateProvider(), "").Convert(context.Background(), m, "JPY")
+	if !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected ErrNoRate, got %v", err)
+	}
+
+	// EUR -> USD -> JPY
+	got, err := NewExchanger(testRateProvider(), "USD").Convert(context.Background(), m, "JPY")
+	if err != nil {
+		t.Errorf("unexpected error %s", err)
+	} else if got.currency.Code != "JPY" || got.String() != "16332.175" {
+		t.Errorf("expected JPY 16332.175, got %s %s", got.currency, got)
+	}
+
+	// Only one leg available
+	_, err = NewExchanger(testRateProvider(), "USD").Convert(context.Background(), RequireFromString("JPY", "100"), "EUR")
+	if !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected ErrNoRate, got %v", err)
+	}
+}
+
+func TestExchanger_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewExchanger(testRateProvider(), "USD").Convert(ctx, RequireFromString("USD", "100"), "EUR")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestExchanger_UnknownCurrency(t *testing.T) {
+	_, err := NewExchanger(testRateProvider(), "USD").Convert(context.Background(), RequireFromString("USD", "100"), "I*am*Not*a*Currency")
+	if err == nil {
+		t.Errorf("expected error for unknown currency")
+	}
+}
+
+func TestExchanger_ConvertAll(t *testing.T) {
+	counter := &countingRateProvider{inner: testRateProvider()}
+	e := NewExchanger(counter, "USD")
+
+	ms := []Money{
+		RequireFromString("USD", "100"),
+		RequireFromString("EUR", "10"),
+		RequireFromString("USD", "1.50"),
+		RequireFromString("EUR", "-20"),
+		RequireFromString("JPY", "1000"),
+		RequireFromString("USD", "2"),
+	}
+
+	// JPY -> EUR isn't possible, so leave it out first
+	got, err := e.ConvertAll(context.Background(), append(ms[:4:4], ms[5]), "EUR")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	expected := []string{"92", "10", "1.38", "-20", "1.84"}
+	for i := range expected {
+		if got[i].currency.Code != "EUR" || got[i].String() != expected[i] {
+			t.Errorf("item %d: expected EUR %s, got %s %s", i, expected[i], got[i].currency, got[i])
+		}
+	}
+
+	// One lookup for USD, and EUR -> EUR needs none
+	if counter.Calls() != 1 {
+		t.Errorf("expected 1 call to the provider, got %d", counter.Calls())
+	}
+
+	// Fails on the JPY
+	got, err = e.ConvertAll(context.Background(), ms, "EUR")
+	if !errors.Is(err, ErrNoRate) || got != nil {
+		t.Errorf("expected ErrNoRate and no result, got %v %v", got, err)
+	} else if !strings.Contains(err.Error(), "[4]") {
+		t.Errorf("expected the error to name item 4, got %s", err)
+	}
+
+	if _, err := e.ConvertAll(context.Background(), ms, "I*am*Not*a*Currency"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+
+	if got, err := e.ConvertAll(context.Background(), nil, "EUR"); err != nil || len(got) != 0 {
+		t.Errorf("expected an empty result, got %v %v", got, err)
+	}
+}
+
+// countingRateProvider counts the calls made to it, and fails on demand.
+type countingRateProvider struct {
+	inner RateProvider
+	calls int64
+	fail  int32
+}
+
+func (p *countingRateProvider) Rate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	atomic.AddInt64(&p.calls, 1)
+	if atomic.LoadInt32(&p.fail) == 1 {
+		return decimal.Zero, errors.New("provider is down")
+	}
+	return p.inner.Rate(ctx, from, to)
+}
+
+func (p *countingRateProvider) Calls() int64 {
+	return atomic.LoadInt64(&p.calls)
+}
+
+func TestCachingRateProvider(t *testing.T) {
+	counter := &countingRateProvider{inner: testRateProvider()}
+	cache := NewCachingRateProvider(counter, time.Minute)
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	// First lookup misses, the rest hit
+	for i := 0; i < 3; i++ {
+		rate, err := cache.Rate(context.Background(), "USD", "EUR")
+		if err != nil || rate.String() != "0.92" {
+			t.Errorf("expected 0.92, got %s (%v)", rate, err)
+		}
+	}
+	if counter.Calls() != 1 {
+		t.Errorf("expected 1 call to the provider, got %d", counter.Calls())
+	}
+
+	// Different pair misses
+	if _, err := cache.Rate(context.Background(), "EUR", "USD"); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	if counter.Calls() != 2 {
+		t.Errorf("expected 2 calls to the provider, got %d", counter.Calls())
+	}
+
+	// Misses aren't cached
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Rate(context.Background(), "EUR", "JPY"); !errors.Is(err, ErrNoRate) {
+			t.Errorf("expected ErrNoRate, got %v", err)
+		}
+	}
+	if counter.Calls() != 4 {
+		t.Errorf("expected 4 calls to the provider, got %d", counter.Calls())
+	}
+
+	// Expire the cache
+	now = now.Add(time.Minute)
+	if _, err := cache.Rate(context.Background(), "USD", "EUR"); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	if counter.Calls() != 5 {
+		t.Errorf("expected 5 calls to the provider, got %d", counter.Calls())
+	}
+
+	// Invalidate
+	cache.Invalidate()
+	if _, err := cache.Rate(context.Background(), "USD", "EUR"); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	if counter.Calls() != 6 {
+		t.Errorf("expected 6 calls to the provider, got %d", counter.Calls())
+	}
+}
+
+func TestCachingRateProvider_ZeroValue(t *testing.T) {
+	counter := &countingRateProvider{inner: testRateProvider()}
+	cache := &CachingRateProvider{Inner: counter, TTL: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		rate, err := cache.Rate(context.Background(), "USD", "EUR")
+		if err != nil || rate.String() != "0.92" {
+			t.Errorf("expected 0.92, got %s (%v)", rate, err)
+		}
+	}
+	if counter.Calls() != 1 {
+		t.Errorf("expected 1 call to the provider, got %d", counter.Calls())
+	}
+
+	cache.Invalidate()
+	if _, err := cache.Rate(context.Background(), "USD", "EUR"); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	if counter.Calls() != 2 {
+		t.Errorf("expected 2 calls to the provider, got %d", counter.Calls())
+	}
+}
+
+func TestCachingRateProvider_Stale(t *testing.T) {
+	counter := &countingRateProvider{inner: testRateProvider()}
+	cache := NewCachingRateProvider(counter, time.Minute)
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	if _, err := cache.Rate(context.Background(), "USD", "EUR"); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+
+	// Provider goes down after the rate expires
+	now = now.Add(2 * time.Minute)
+	atomic.StoreInt32(&counter.fail, 1)
+
+	if _, err := cache.Rate(context.Background(), "USD", "EUR"); err == nil {
+		t.Errorf("expected error without ServeStale")
+	}
+
+	cache.ServeStale = true
+	rate, err := cache.Rate(context.Background(), "USD", "EUR")
+	if err != nil || rate.String() != "0.92" {
+		t.Errorf("expected stale 0.92, got %s (%v)", rate, err)
+	}
+
+	// Nothing stale to serve for a pair we've never seen
+	if _, err := cache.Rate(context.Background(), "EUR", "USD"); err == nil {
+		t.Errorf("expected error with nothing cached")
+	}
+}
+
+func TestCachingRateProvider_Concurrent(t *testing.T) {
+	counter := &countingRateProvider{inner: testRateProvider()}
+	cache := NewCachingRateProvider(counter, time.Hour)
+
+	// Prime it, then hammer it
+	if _, err := cache.Rate(context.Background(), "USD", "EUR"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := cache.Rate(context.Background(), "USD", "EUR"); err != nil {
+				t.Errorf("unexpected error %s", err)
+			}
+			if i%10 == 0 {
+				cache.Invalidate()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Misses are coalesced, and the cache only empties when it's invalidated,
+	// so there's at most one lookup for the priming and one per Invalidate
+	if counter.Calls() > 6 {
+		t.Errorf("expected at most 6 calls to the provider, got %d", counter.Calls())
+	}
+}
+
+// blockingRateProvider holds every lookup until release is closed.
+type blockingRateProvider struct {
+	countingRateProvider
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingRateProvider) Rate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	p.started <- struct{}{}
+	select {
+	case <-p.release:
+	case <-ctx.Done():
+		return decimal.Zero, ctx.Err()
+	}
+	return p.countingRateProvider.Rate(ctx, from, to)
+}
+
+func TestCachingRateProvider_Coalesce(t *testing.T) {
+	blocking := &blockingRateProvider{
+		countingRateProvider: countingRateProvider{inner: testRateProvider()},
+		started:              make(chan struct{}, 10),
+		release:              make(chan struct{}),
+	}
+	cache := NewCachingRateProvider(blocking, time.Hour)
+
+	// One lookup is in flight before anyone else misses
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cache.Rate(context.Background(), "USD", "EUR")
+	}()
+	<-blocking.started
+
+	rates := make([]decimal.Decimal, 10)
+	for i := range rates {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rates[i], _ = cache.Rate(context.Background(), "USD", "EUR")
+		}(i)
+	}
+
+	// A waiter gives up when its own context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cache.Rate(ctx, "USD", "EUR"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	close(blocking.release)
+	wg.Wait()
+
+	if calls := blocking.Calls(); calls != 1 {
+		t.Errorf("expected 1 call to the provider, got %d", calls)
+	}
+	for i, rate := range rates {
+		if rate.String() != "0.92" {
+			t.Errorf("caller %d: expected 0.92 got %s", i, rate)
+		}
+	}
+}
+
+func TestCachingRateProvider_CancelledLeader(t *testing.T) {
+	blocking := &blockingRateProvider{
+		countingRateProvider: countingRateProvider{inner: testRateProvider()},
+		started:              make(chan struct{}, 10),
+		release:              make(chan struct{}),
+	}
+	cache := NewCachingRateProvider(blocking, time.Hour)
+
+	// The first caller starts the lookup, then gives up on it
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := cache.Rate(ctx, "USD", "EUR")
+		leaderErr <- err
+	}()
+	<-blocking.started
+
+	var rate decimal.Decimal
+	var err error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rate, err = cache.Rate(context.Background(), "USD", "EUR")
+	}()
+
+	// Give the second caller a moment to start waiting on the first
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled for the first caller, got %v", err)
+	}
+
+	// The second caller takes over the lookup rather than sharing the cancellation
+	select {
+	case <-blocking.started:
+	case <-time.After(time.Second):
+		t.Fatal("expected the second caller to retry the lookup")
+	}
+	close(blocking.release)
+	<-done
+
+	if err != nil || rate.String() != "0.92" {
+		t.Errorf("expected 0.92, got %s (%v)", rate, err)
+	}
+}
@@ -8,15 +8,32 @@ package money
 import (
 	"github.com/shopspring/decimal"
 	"strings"
+	"unicode"
 )
 
 // Formatter stores Money formatting information
+//
+// If ZeroDisplay is set, it's returned in place of any amount that rounds to
+// zero, ie. "\u2014" for the accounting style dash. Left empty, zero is
+// formatted like any other number.
+//
+// If TrailingDebitCredit is set, the sign is shown as a label from DebitCredit
+// after the amount, ie. "1,234.56 DR", instead of a minus sign or brackets.
+// Zero only gets a label if DebitCredit.Zero isn't empty.
+//
+// Grouping and Spacing are as on Currency: how the digits are grouped, and the
+// gap between the grapheme and the amount.
 type Formatter struct {
-	Fraction int
-	DecPoint string
-	Thousand string
-	Grapheme string
-	Template string
+	Fraction            int
+	DecPoint            string
+	Thousand            string
+	Grapheme            string
+	Template            string
+	Grouping            GroupingStyle
+	Spacing             SymbolSpacing
+	ZeroDisplay         string
+	TrailingDebitCredit bool
+	DebitCredit         SignNames
 }
 
 // NewFormatter creates new Formatter instance
@@ -32,47 +49,54 @@ func NewFormatter(fraction int, decpoint, thousand, grapheme, template string) *
 
 // FormatWithOptions returns string of formatted integer using given currency template
 //		amount: The amount to be displayed
+//		mode: How to round amount to Fraction digits, RoundHalfEven for the usual bank rounding
 //		noThousands : Boolean - If true, don't bother with the thousands separator.
 //		noCurrencyGrapheme: Boolean - If true, we'll hide the $ (or whatever) symbol
 //		negsInBrackets: Boolean - If true, we'll display negative numbers as "($1,000.00)" as opposed to "-$100.00"
-func (f *Formatter) formatWithOptions(amount decimal.Decimal, noThousands, noCurrencyGrapheme, negsInBrackets bool) string {
+func (f *Formatter) formatWithOptions(amount decimal.Decimal, mode RoundingMode, noThousands, noCurrencyGrapheme, negsInBrackets bool) string {
 
 	// Work with absolute amount value
-	// Then print as a Bank Rounded number to the display amount based on the currency
+	// Then print as a number rounded with mode to the display amount based on the currency
 	// Then split into int and fractional parts for correct formatting
-	numBits := strings.Split(amount.Abs().StringFixedBank(int32(f.Fraction)), ".")
-
-	fractionalPart := ""
-	intPart := numBits[0]
-	if len(numBits) > 1 {
-		fractionalPart = numBits[1]
+	rounded := roundDecimal(amount, int32(f.Fraction), mode)
+	if f.ZeroDisplay != "" && rounded.Sign() == 0 {
+		return f.ZeroDisplay
 	}
 
-	if !noThousands {
-		if f.Thousand != "" {
-			for i := len(intPart) - 3; i > 0; i -= 3 {
-				intPart = intPart[:i] + f.Thousand + intPart[i:]
-			}
-		}
-	}
+	intPart, fractionalPart := f.numberParts(rounded, noThousands)
 
 	// Time to combine. Hijacking intPart because renaming is pointless now.
 	if len(fractionalPart) > 0 {
 		intPart += f.DecPoint + fractionalPart
 	}
 
-	// Got the number looking nice, now for the trimmings
-	intPart = strings.Replace(f.Template, "1", intPart, 1)
-
-	// Add (or hide) the currency grapheme
+	// Got the number looking nice, now for the trimmings. Add (or hide) the
+	// currency grapheme either side of the number, so nothing in the number
+	// itself can be mistaken for part of the template.
+	grapheme := f.Grapheme
 	if noCurrencyGrapheme {
-		intPart = strings.TrimSpace(strings.Replace(intPart, "$", "", 1))
-	} else {
-		intPart = strings.Replace(intPart, "$", f.Grapheme, 1)
+		grapheme = ""
+	}
+	prefix, suffix := f.templateParts(grapheme)
+	intPart = prefix + intPart + suffix
+	if noCurrencyGrapheme {
+		intPart = strings.TrimSpace(intPart)
 	}
 
-	// Add minus sign for negative amount
-	if amount.Sign() < 0 {
+	// Add minus sign for negative amount. Checking the rounded amount so that
+	// anything which displays as zero doesn't come out as "-$0.00"
+	if f.TrailingDebitCredit {
+		label := f.DebitCredit.Positive
+		switch rounded.Sign() {
+		case -1:
+			label = f.DebitCredit.Negative
+		case 0:
+			label = f.DebitCredit.Zero
+		}
+		if label != "" {
+			intPart += " " + label
+		}
+	} else if rounded.Sign() < 0 {
 		if negsInBrackets {
 			intPart = "(" + intPart + ")"
 		} else {
@@ -83,14 +107,214 @@ func (f *Formatter) formatWithOptions(amount decimal.Decimal, noThousands, noCur
 	return intPart
 }
 
+// numberParts splits the absolute value of rounded into its integer part,
+// grouped with Thousand unless noThousands is set, and its fractional part,
+// padded to Fraction digits.
+func (f *Formatter) numberParts(rounded decimal.Decimal, noThousands bool) (intPart, fractionalPart string) {
+
+	numBits := strings.Split(rounded.Abs().StringFixedBank(int32(f.Fraction)), ".")
+
+	intPart = numBits[0]
+	if len(numBits) > 1 {
+		fractionalPart = numBits[1]
+	}
+
+	// intPart only holds ASCII digits, and we work from the right, so the
+	// offsets still line up when Thousand is multi-byte (ie. a NBSP). The
+	// first group is always three digits, the rest depend on the style.
+	if !noThousands && f.Thousand != "" {
+		step := 3
+		if f.Grouping == GroupIndian {
+			step = 2
+		}
+		for i := len(intPart) - 3; i > 0; i -= step {
+			intPart = intPart[:i] + f.Thousand + intPart[i:]
+		}
+	}
+
+	return intPart, fractionalPart
+}
+
+// checkGrouping returns an error unless number, what's left of s once the sign
+// and template are stripped, has no Thousand separators or has them just where
+// numberParts puts them, and has digits after DecPoint if that's there.
+func (f *Formatter) checkGrouping(s, number string) error {
+
+	intPart := number
+	if f.DecPoint != "" {
+		if i := strings.Index(number, f.DecPoint); i >= 0 {
+			intPart = number[:i]
+			if i+len(f.DecPoint) == len(number) {
+				return newError(ErrParse, nil, "Cannot parse '%s': no digits after the decimal point [%s]", s, f.DecPoint)
+			}
+		}
+	}
+
+	if f.Thousand == "" || !strings.Contains(intPart, f.Thousand) {
+		return nil
+	}
+
+	// The last group is always three digits, the ones before it depend on the
+	// style, and the first may be short
+	step := 3
+	if f.Grouping == GroupIndian {
+		step = 2
+	}
+
+	groups := strings.Split(intPart, f.Thousand)
+	for i, g := range groups {
+		want := step
+		if i == len(groups)-1 {
+			want = 3
+		}
+		if len(g) == 0 || len(g) > want || (i > 0 && len(g) != want) {
+			return newError(ErrParse, nil, "Cannot parse '%s': misplaced thousands separator [%s]", s, f.Thousand)
+		}
+	}
+
+	return nil
+}
+
+// templateParts splits the template either side of the amount placeholder "1",
+// with grapheme swapped in for the "$" placeholder.
+func (f *Formatter) templateParts(grapheme string) (prefix, suffix string) {
+	i := strings.Index(f.Template, "1")
+	if i < 0 {
+		return "", ""
+	}
+
+	prefix, suffix = f.Template[:i], f.Template[i+1:]
+	if strings.Contains(prefix, "$") {
+		prefix = strings.Replace(prefix, "$", grapheme, 1)
+		if grapheme != "" && f.Spacing != SpacingTemplate {
+			prefix = strings.TrimRightFunc(prefix, unicode.IsSpace) + f.gap()
+		}
+	} else {
+		suffix = strings.Replace(suffix, "$", grapheme, 1)
+		if grapheme != "" && f.Spacing != SpacingTemplate && strings.Contains(f.Template[i+1:], "$") {
+			suffix = f.gap() + strings.TrimLeftFunc(suffix, unicode.IsSpace)
+		}
+	}
+
+	return prefix, suffix
+}
+
+// gap returns the space Spacing puts between the grapheme and the amount.
+func (f *Formatter) gap() string {
+	switch f.Spacing {
+	case SpacingSpace:
+		return " "
+	case SpacingThinSpace:
+		return "\u2009"
+	}
+	return ""
+}
+
 // Format returns string of formatted integer using given currency template
 //		amount: The amount to be displayed
 func (f *Formatter) FormatAccounting(amount decimal.Decimal) string {
-	return f.formatWithOptions(amount, true, true, true)
+	return f.formatWithOptions(amount, RoundHalfEven, true, true, true)
 }
 
 // Format returns string of formatted integer using given currency template
 //		amount: The amount to be displayed
 func (f *Formatter) FormatCurrency(amount decimal.Decimal) string {
-	return f.formatWithOptions(amount, false, false, false)
+	return f.formatWithOptions(amount, RoundHalfEven, false, false, false)
+}
+
+// FormatAccountingMode is FormatAccounting, but rounding amount for display
+// with mode rather than banker's rounding.
+func (f *Formatter) FormatAccountingMode(amount decimal.Decimal, mode RoundingMode) string {
+	return f.formatWithOptions(amount, mode, true, true, true)
+}
+
+// FormatCurrencyMode is FormatCurrency, but rounding amount for display with
+// mode rather than banker's rounding, so what's shown matches amounts rounded
+// elsewhere with the same mode.
+//
+// Example:
+//
+//     f.FormatCurrency(decimal.RequireFromString("2.125"))                  // output: "$2.12"
+//     f.FormatCurrencyMode(decimal.RequireFromString("2.125"), RoundHalfUp) // output: "$2.13"
+func (f *Formatter) FormatCurrencyMode(amount decimal.Decimal, mode RoundingMode) string {
+	return f.formatWithOptions(amount, mode, false, false, false)
+}
+
+// Parse undoes FormatCurrency and FormatAccounting, returning the amount that was
+// formatted. The grapheme (as placed by the template) and Thousand separators are
+// stripped, DecPoint is turned back into a ".", and a leading "-" or surrounding
+// brackets are treated as negative.
+//
+// Example:
+//
+//     f := NewFormatter(2, ",", ".", "\u20ac", "1 $")
+//     f.Parse("-1.234,56 \u20ac") // output: -1234.56
+//     f.Parse("(1234,56)")      // output: -1234.56
+//
+// An error is returned if anything other than the number is left over once the
+// known tokens have been removed.
+func (f *Formatter) Parse(s string) (decimal.Decimal, error) {
+	return f.parse(s, false)
+}
+
+// parse is Parse. If strict is set, the number must also be ungrouped or
+// grouped just as numberParts groups it, and a DecPoint must have digits after
+// it, so a mangled amount ("12,34", "1,,000") can't pass for another one.
+func (f *Formatter) parse(s string, strict bool) (decimal.Decimal, error) {
+
+	str := strings.TrimSpace(s)
+	if f.ZeroDisplay != "" && str == f.ZeroDisplay {
+		return decimal.Zero, nil
+	}
+
+	// Sort out the sign first, as it wraps everything else
+	negative := false
+	if len(str) > 1 && strings.HasPrefix(str, "(") && strings.HasSuffix(str, ")") {
+		negative = true
+		str = str[1 : len(str)-1]
+	} else if strings.HasPrefix(str, "-") {
+		negative = true
+		str = str[1:]
+	}
+
+	// Strip whatever the template puts around the number, grapheme included
+	prefix, suffix := f.templateParts(f.Grapheme)
+	if prefix != "" && strings.HasPrefix(str, prefix) {
+		str = str[len(prefix):]
+	}
+	if suffix != "" && strings.HasSuffix(str, suffix) {
+		str = str[:len(str)-len(suffix)]
+	}
+	str = strings.TrimSpace(str)
+
+	if strict {
+		if err := f.checkGrouping(s, str); err != nil {
+			return decimal.Zero, err
+		}
+	}
+
+	// Back to a plain number
+	if f.Thousand != "" {
+		str = strings.Replace(str, f.Thousand, "", -1)
+	}
+	if f.DecPoint != "" && f.DecPoint != "." {
+		str = strings.Replace(str, f.DecPoint, ".", 1)
+	}
+
+	for _, r := range str {
+		if (r < '0' || r > '9') && r != '.' {
+			return decimal.Zero, newError(ErrParse, nil, "Cannot parse '%s': unexpected character '%c'", s, r)
+		}
+	}
+
+	d, err := decimal.NewFromString(str)
+	if err != nil {
+		return decimal.Zero, newError(ErrParse, err, "Cannot parse '%s': %s", s, err)
+	}
+
+	if negative {
+		d = d.Neg()
+	}
+
+	return d, nil
 }
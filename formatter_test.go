@@ -169,3 +169,337 @@ func TestFormatter_FormatWithCurrency(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatter_NoNegativeZero(t *testing.T) {
+	formatter := NewFormatter(2, ".", ",", "$", "$1")
+
+	tcs := []struct {
+		amount     decimal.Decimal
+		currency   string
+		accounting string
+	}{
+		{decimal.New(0, 0), "$0.00", "0.00"},
+		{decimal.New(0, 0).Neg(), "$0.00", "0.00"},
+		{decimal.New(-1, -3), "$0.00", "0.00"},
+		{decimal.New(-5, -3), "$0.00", "0.00"},
+		{decimal.New(-6, -3), "-$0.01", "(0.01)"},
+	}
+
+	for _, tc := range tcs {
+		if r := formatter.FormatCurrency(tc.amount); r != tc.currency {
+			t.Errorf("Expected %s formatted to be %s got %s", tc.amount, tc.currency, r)
+		}
+		if r := formatter.FormatAccounting(tc.amount); r != tc.accounting {
+			t.Errorf("Expected %s formatted to be %s got %s", tc.amount, tc.accounting, r)
+		}
+	}
+}
+
+func TestFormatter_ZeroDisplay(t *testing.T) {
+	formatter := NewFormatter(2, ".", ",", "$", "$1")
+	formatter.ZeroDisplay = AccountingDash
+
+	tcs := []struct {
+		amount     decimal.Decimal
+		currency   string
+		accounting string
+	}{
+		{decimal.New(0, 0), "\u2014", "\u2014"},
+		{decimal.New(-4, -3), "\u2014", "\u2014"},
+		{decimal.New(5, -3), "\u2014", "\u2014"},
+		{decimal.New(6, -3), "$0.01", "0.01"},
+		{decimal.New(-123456, -2), "-$1,234.56", "(1234.56)"},
+	}
+
+	for _, tc := range tcs {
+		if r := formatter.FormatCurrency(tc.amount); r != tc.currency {
+			t.Errorf("Expected %s formatted to be %s got %s", tc.amount, tc.currency, r)
+		}
+		if r := formatter.FormatAccounting(tc.amount); r != tc.accounting {
+			t.Errorf("Expected %s formatted to be %s got %s", tc.amount, tc.accounting, r)
+		}
+	}
+
+	if d, err := formatter.Parse("\u2014"); err != nil || d.Sign() != 0 {
+		t.Errorf("Expected the dash to parse as zero, got %s %v", d, err)
+	}
+
+	// Off by default
+	if r := NewFormatter(2, ".", ",", "$", "$1").FormatCurrency(decimal.Zero); r != "$0.00" {
+		t.Errorf("Expected $0.00 got %s", r)
+	}
+}
+
+func TestFormatter_RoundingMode(t *testing.T) {
+	formatter := NewFormatter(2, ".", ",", "$", "$1")
+
+	tcs := []struct {
+		amount     string
+		mode       RoundingMode
+		currency   string
+		accounting string
+	}{
+		{"2.125", RoundHalfEven, "$2.12", "2.12"},
+		{"2.125", RoundHalfUp, "$2.13", "2.13"},
+		{"-2.125", RoundHalfEven, "-$2.12", "(2.12)"},
+		{"-2.125", RoundHalfUp, "-$2.13", "(2.13)"},
+		{"1234.561", RoundCeil, "$1,234.57", "1234.57"},
+		{"-0.001", RoundFloor, "-$0.01", "(0.01)"},
+		{"-0.001", RoundHalfUp, "$0.00", "0.00"},
+	}
+
+	for _, tc := range tcs {
+		amount := decimal.RequireFromString(tc.amount)
+		if r := formatter.FormatCurrencyMode(amount, tc.mode); r != tc.currency {
+			t.Errorf("Expected %s formatted with mode %d to be %s got %s", tc.amount, tc.mode, tc.currency, r)
+		}
+		if r := formatter.FormatAccountingMode(amount, tc.mode); r != tc.accounting {
+			t.Errorf("Expected %s formatted with mode %d to be %s got %s", tc.amount, tc.mode, tc.accounting, r)
+		}
+	}
+
+	// Bank rounding is still the default
+	if r := formatter.FormatCurrency(decimal.RequireFromString("2.125")); r != "$2.12" {
+		t.Errorf("Expected $2.12 got %s", r)
+	}
+}
+
+func TestFormatter_TrailingDebitCredit(t *testing.T) {
+	formatter := NewFormatter(2, ".", ",", "$", "$1")
+	formatter.TrailingDebitCredit = true
+	formatter.DebitCredit = SignNames{Negative: "DR", Positive: "CR"}
+
+	tcs := []struct {
+		amount     decimal.Decimal
+		currency   string
+		accounting string
+	}{
+		{decimal.New(-123456, -2), "$1,234.56 DR", "1234.56 DR"},
+		{decimal.New(123456, -2), "$1,234.56 CR", "1234.56 CR"},
+		{decimal.New(0, 0), "$0.00", "0.00"},
+		{decimal.New(-4, -3), "$0.00", "0.00"},
+	}
+
+	for _, tc := range tcs {
+		if r := formatter.FormatCurrency(tc.amount); r != tc.currency {
+			t.Errorf("Expected %s formatted to be %s got %s", tc.amount, tc.currency, r)
+		}
+		if r := formatter.FormatAccounting(tc.amount); r != tc.accounting {
+			t.Errorf("Expected %s formatted to be %s got %s", tc.amount, tc.accounting, r)
+		}
+	}
+
+	// Zero can have its own label, and the labels are free text
+	formatter.DebitCredit = SignNames{Negative: "Dr", Zero: "Nil", Positive: "Cr"}
+	if r := formatter.FormatCurrency(decimal.Zero); r != "$0.00 Nil" {
+		t.Errorf("Expected $0.00 Nil got %s", r)
+	}
+	if r := formatter.FormatCurrency(decimal.New(-5, 0)); r != "$5.00 Dr" {
+		t.Errorf("Expected $5.00 Dr got %s", r)
+	}
+}
+
+func TestFormatter_Grouping(t *testing.T) {
+	tcs := []struct {
+		code     string
+		amount   string
+		expected string
+	}{
+		{"INR", "1234567.89", "\u20b912,34,567.89"},
+		{"INR", "-1234567.89", "-\u20b912,34,567.89"},
+		{"INR", "123456789", "\u20b912,34,56,789.00"},
+		{"INR", "100000", "\u20b91,00,000.00"},
+		{"INR", "12345", "\u20b912,345.00"},
+		{"INR", "1234", "\u20b91,234.00"},
+		{"INR", "999", "\u20b9999.00"},
+		{"INR", "-0.5", "-\u20b90.50"},
+		{"EUR", "1234567.89", "\u20ac1,234,567.89"},
+		{"EUR-DE", "1234567.89", "1\u00a0234\u00a0567,89\u00a0\u20ac"},
+		{"USD", "123456789", "$123,456,789.00"},
+	}
+
+	for _, tc := range tcs {
+		if r := RequireFromString(tc.code, tc.amount).FormattedStringBank(); r != tc.expected {
+			t.Errorf("Expected %s %s formatted to be %s got %s", tc.code, tc.amount, tc.expected, r)
+		}
+	}
+
+	// Without the thousands there's nothing to group
+	if r := RequireFromString("INR", "-1234567.89").FormattedStringAccounting(); r != "(1234567.89)" {
+		t.Errorf("Expected (1234567.89) got %s", r)
+	}
+
+	formatter := NewFormatter(2, ".", ",", "$", "$1")
+	formatter.Grouping = GroupIndian
+	if d, err := formatter.Parse("$12,34,567.89"); err != nil || d.String() != "1234567.89" {
+		t.Errorf("Expected 1234567.89 got %s %v", d, err)
+	}
+}
+
+func TestFormatter_Spacing(t *testing.T) {
+	tcs := []struct {
+		template string
+		spacing  SymbolSpacing
+		expected string
+	}{
+		{"$1", SpacingTemplate, "-$1,234.56"},
+		{"$1", SpacingNone, "-$1,234.56"},
+		{"$1", SpacingSpace, "-$ 1,234.56"},
+		{"$1", SpacingThinSpace, "-$\u20091,234.56"},
+		{"$ 1", SpacingTemplate, "-$ 1,234.56"},
+		{"$ 1", SpacingNone, "-$1,234.56"},
+		{"$ 1", SpacingThinSpace, "-$\u20091,234.56"},
+		{"1 $", SpacingTemplate, "-1,234.56 $"},
+		{"1 $", SpacingNone, "-1,234.56$"},
+		{"1\u00a0$", SpacingSpace, "-1,234.56 $"},
+		{"1$", SpacingThinSpace, "-1,234.56\u2009$"},
+	}
+
+	for _, tc := range tcs {
+		formatter := NewFormatter(2, ".", ",", "$", tc.template)
+		formatter.Spacing = tc.spacing
+
+		if r := formatter.FormatCurrency(decimal.New(-123456, -2)); r != tc.expected {
+			t.Errorf("Expected %q with %d to be %q got %q", tc.template, tc.spacing, tc.expected, r)
+		}
+		if d, err := formatter.Parse(tc.expected); err != nil || d.String() != "-1234.56" {
+			t.Errorf("Expected %q to parse as -1234.56 got %s %v", tc.expected, d, err)
+		}
+
+		// No grapheme, no gap
+		if r := formatter.FormatAccounting(decimal.New(123456, -2)); r != "1234.56" {
+			t.Errorf("Expected %q with %d to be 1234.56 got %q", tc.template, tc.spacing, r)
+		}
+	}
+
+	// From the currency
+	c, err := NewCurrencyBuilder("#SP").Grapheme("$").Spacing(SpacingThinSpace).Register()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	defer delete(currencies, c.Code)
+
+	if r := RequireFromString("#SP", "1234.56").FormattedStringBank(); r != "$\u20091,234.56" {
+		t.Errorf("Expected $\u20091,234.56 got %q", r)
+	}
+	if r := RequireFromString("USD", "1234.56").FormattedStringBank(); r != "$1,234.56" {
+		t.Errorf("Expected $1,234.56 got %q", r)
+	}
+}
+
+func TestFormatter_Parse(t *testing.T) {
+	tcs := []struct {
+		fraction int
+		decimal  string
+		thousand string
+		grapheme string
+		template string
+		amount   int64
+	}{
+		{2, ".", ",", "$", "1 $", 0},
+		{2, ".", ",", "$", "1 $", 123456789},
+		{2, ".", ",", "$", "1 $", -123456789},
+		{3, ".", "", "$", "1 $", 1234567},
+		{2, ".", ",", "£", "$1", 12},
+		{2, ".", ",", "£", "$1", -123456},
+		{0, ".", ",", "NT$", "$1", 1234567},
+		{0, ".", ",", "NT$", "$1", -123456789},
+		{2, ",", ".", "€", "1 $", 123456},
+		{2, ",", ".", "€", "1 $", -123456789},
+		{2, ".", ",", "Gs", "1$", -1234},
+		{3, "|", "_", "TEST#01:", "$1", 123456789},
+		{3, "|", "_", "TEST#01:", "$1", -1234},
+	}
+
+	for _, tc := range tcs {
+		formatter := NewFormatter(tc.fraction, tc.decimal, tc.thousand, tc.grapheme, tc.template)
+		expected := decimal.New(tc.amount, int32(-tc.fraction))
+
+		for _, s := range []string{formatter.FormatCurrency(expected), formatter.FormatAccounting(expected)} {
+			d, err := formatter.Parse(s)
+			if err != nil {
+				t.Errorf("Unexpected error parsing %s: %s", s, err)
+			} else if !d.Equal(expected) {
+				t.Errorf("Expected %s to parse to %s got %s", s, expected, d)
+			}
+		}
+	}
+}
+
+func TestFormatter_ParseErrs(t *testing.T) {
+	formatter := NewFormatter(2, ".", ",", "$", "$1")
+
+	for _, s := range []string{
+		"",
+		"$",
+		"()",
+		"€1.23",
+		"$1.23 USD",
+		"$1.2.3",
+		"$1e5",
+		"$12a",
+		"--$1.00",
+	} {
+		if d, err := formatter.Parse(s); err == nil {
+			t.Errorf("Expected error parsing %q, got %s", s, d)
+		}
+	}
+}
+
+func TestFormatter_NonBreakingSpace(t *testing.T) {
+	tcs := []struct {
+		testtype int
+		template string
+		amount   int64
+		expected string
+	}{
+		{1, "1 $", 5, "0,05 \u20ac"},
+		{1, "1 $", 123456, "1\u00a0234,56 \u20ac"},
+		{1, "1 $", 123456789, "1\u00a0234\u00a0567,89 \u20ac"},
+		{1, "1 $", -123456789, "-1\u00a0234\u00a0567,89 \u20ac"},
+		{1, "1\u00a0$", 123456, "1\u00a0234,56\u00a0\u20ac"},
+		{1, "$1", 123456, "\u20ac1\u00a0234,56"},
+		{2, "1 $", -123456789, "(1234567,89)"},
+		{2, "1\u00a0$", -123456789, "(1234567,89)"},
+	}
+
+	for _, tc := range tcs {
+		formatter := NewFormatter(2, ",", "\u00a0", "\u20ac", tc.template)
+
+		var r string
+		if tc.testtype == 2 {
+			r = formatter.FormatAccounting(decimal.New(tc.amount, -2))
+		} else {
+			r = formatter.FormatCurrency(decimal.New(tc.amount, -2))
+		}
+		if r != tc.expected {
+			t.Errorf("Expected %d formatted to be %q got %q", tc.amount, tc.expected, r)
+		}
+
+		if d, err := formatter.Parse(r); err != nil || !d.Equal(decimal.New(tc.amount, -2)) {
+			t.Errorf("Expected %q to parse back to %d got %s (%v)", r, tc.amount, d, err)
+		}
+	}
+}
+
+func TestFormatter_EURDE(t *testing.T) {
+	c := MustGetCurrency("EUR-DE")
+
+	if r := c.Formatter().FormatCurrency(decimal.New(123456, -2)); r != "1\u00a0234,56\u00a0\u20ac" {
+		t.Errorf("Expected %q got %q", "1\u00a0234,56\u00a0\u20ac", r)
+	}
+	if r := c.Formatter().FormatCurrency(decimal.New(-123456789, -2)); r != "-1\u00a0234\u00a0567,89\u00a0\u20ac" {
+		t.Errorf("Expected %q got %q", "-1\u00a0234\u00a0567,89\u00a0\u20ac", r)
+	}
+	if r := c.Formatter().FormatAccounting(decimal.New(-123456, -2)); r != "(1234,56)" {
+		t.Errorf("Expected %q got %q", "(1234,56)", r)
+	}
+}
+
+func TestFormatter_GraphemeWithPlaceholders(t *testing.T) {
+	// Neither the grapheme nor the separators should be mistaken for the template
+	formatter := NewFormatter(2, "$", "1", "1$", "$1")
+	if r := formatter.FormatCurrency(decimal.New(123456, -2)); r != "1$11234$56" {
+		t.Errorf("Expected %q got %q", "1$11234$56", r)
+	}
+}
@@ -0,0 +1,131 @@
+// package money - Amounts in words
+// Cheques, and some legal documents, want the amount written out in full as
+// well as in figures. English only, in the style US cheques use.
+
+package money
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	wordOnes = []string{
+		"Zero", "One", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine",
+		"Ten", "Eleven", "Twelve", "Thirteen", "Fourteen", "Fifteen", "Sixteen",
+		"Seventeen", "Eighteen", "Nineteen",
+	}
+	wordTens = []string{
+		"", "", "Twenty", "Thirty", "Forty", "Fifty", "Sixty", "Seventy", "Eighty", "Ninety",
+	}
+	wordScales = []string{
+		"", "Thousand", "Million", "Billion", "Trillion", "Quadrillion", "Quintillion",
+		"Sextillion", "Septillion", "Octillion", "Nonillion", "Decillion",
+	}
+)
+
+// AmountInWords returns m written out in English for a cheque, with the minor
+// units as a fraction of the major unit, rounded to the currency's Fraction
+// using DefaultRoundingMode.
+//
+// Example:
+//
+//	RequireFromString("USD", "123.45").AmountInWords() // "One Hundred Twenty-Three and 45/100 Dollars"
+//	RequireFromString("USD", "-1").AmountInWords()     // "Minus One and 00/100 Dollars"
+//	RequireFromString("USD", "0").AmountInWords()      // "Zero Dollars"
+//	RequireFromString("JPY", "1000").AmountInWords()   // "One Thousand Yen"
+//
+// An error is returned if the currency has no MajorUnitName, or the amount is
+// too large to have a name.
+func (m Money) AmountInWords() (string, error) {
+	m.ensureInitialized()
+
+	if m.currency.MajorUnitName == "" {
+		return "", newError(ErrInvalidArgument, nil, "Cannot write [%s] in words, currency [%s] has no unit name", m.amount, m.currency.Code)
+	}
+
+	rounded := m.roundToFraction(DefaultRoundingMode)
+	numBits := strings.Split(rounded.amount.Abs().StringFixed(int32(m.currency.Fraction)), ".")
+
+	words, err := integerInWords(numBits[0])
+	if err != nil {
+		return "", newError(ErrInvalidArgument, err, "Cannot write [%s] in words: %s", m.amount, err)
+	}
+
+	// The fraction is always written out, so nothing can be added after the
+	// cheque is signed, except when there's nothing at all
+	count := numBits[0]
+	if len(numBits) > 1 && rounded.amount.Sign() != 0 {
+		words += " and " + numBits[1] + "/1" + strings.Repeat("0", len(numBits[1]))
+		count = ""
+	}
+
+	words += " " + titleWords(unitName(m.currency.MajorUnitName, count))
+	if rounded.amount.Sign() < 0 {
+		words = "Minus " + words
+	}
+
+	return words, nil
+}
+
+// integerInWords writes out digits, a whole number with no sign, in words.
+func integerInWords(digits string) (string, error) {
+
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return wordOnes[0], nil
+	}
+
+	groups := (len(digits) + 2) / 3
+	if groups > len(wordScales) {
+		return "", errors.New("too large to write in words")
+	}
+
+	// Pad to whole groups of three, then work from the largest scale down
+	digits = strings.Repeat("0", groups*3-len(digits)) + digits
+
+	var words []string
+	for g := 0; g < groups; g++ {
+		chunk := digits[g*3 : g*3+3]
+		if chunk == "000" {
+			continue
+		}
+
+		words = append(words, hundredsInWords(chunk))
+		if scale := wordScales[groups-g-1]; scale != "" {
+			words = append(words, scale)
+		}
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// hundredsInWords writes out a three digit chunk, which isn't "000".
+func hundredsInWords(chunk string) string {
+	h, t, o := int(chunk[0]-'0'), int(chunk[1]-'0'), int(chunk[2]-'0')
+
+	var words []string
+	if h > 0 {
+		words = append(words, wordOnes[h], "Hundred")
+	}
+
+	switch {
+	case t >= 2 && o > 0:
+		words = append(words, wordTens[t]+"-"+wordOnes[o])
+	case t >= 2:
+		words = append(words, wordTens[t])
+	case t*10+o > 0:
+		words = append(words, wordOnes[t*10+o])
+	}
+
+	return strings.Join(words, " ")
+}
+
+// titleWords upper cases the first letter of each word in s.
+func titleWords(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
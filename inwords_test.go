@@ -0,0 +1,55 @@
+package money
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMoney_AmountInWords(t *testing.T) {
+	tests := []struct {
+		curr     string
+		amount   string
+		expected string
+	}{
+		{"USD", "123.45", "One Hundred Twenty-Three and 45/100 Dollars"},
+		{"USD", "0.00", "Zero Dollars"},
+		{"USD", "0.001", "Zero Dollars"},
+		{"USD", "0.05", "Zero and 05/100 Dollars"},
+		{"USD", "1", "One and 00/100 Dollars"},
+		{"USD", "-1234.5", "Minus One Thousand Two Hundred Thirty-Four and 50/100 Dollars"},
+		{"USD", "1000000.01", "One Million and 01/100 Dollars"},
+		{"USD", "2019017.10", "Two Million Nineteen Thousand Seventeen and 10/100 Dollars"},
+		{"USD", "0.125", "Zero and 12/100 Dollars"},
+		{"JPY", "1000", "One Thousand Yen"},
+		{"JPY", "1", "One Yen"},
+		{"JPY", "40", "Forty Yen"},
+		{"GBP", "1.01", "One and 01/100 Pounds"},
+		{"KWD", "12.345", "Twelve and 345/1000 Dinars"},
+	}
+
+	for _, test := range tests {
+		got, err := RequireFromString(test.curr, test.amount).AmountInWords()
+		if err != nil {
+			t.Errorf("%s %s: unexpected error %s", test.curr, test.amount, err)
+		} else if got != test.expected {
+			t.Errorf("%s %s: expected %q got %q", test.curr, test.amount, test.expected, got)
+		}
+	}
+}
+
+func TestMoney_AmountInWordsErrs(t *testing.T) {
+	if _, err := RequireFromString("BTC", "1").AmountInWords(); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument for a currency without a unit name, got %v", err)
+	}
+
+	huge := RequireFromString("USD", "1"+strings.Repeat("0", 36))
+	if _, err := huge.AmountInWords(); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument for an amount too large to name, got %v", err)
+	}
+
+	// The largest that can be named
+	if got, err := RequireFromString("USD", strings.Repeat("9", 36)).AmountInWords(); err != nil || !strings.HasPrefix(got, "Nine Hundred Ninety-Nine Decillion") {
+		t.Errorf("expected Nine Hundred Ninety-Nine Decillion..., got %q (%v)", got, err)
+	}
+}
@@ -0,0 +1,96 @@
+// package money - Locale formatting
+// Separators and symbol placement depend on where the reader is as much as on
+// the currency, ie. euros are written "1.234,56 €" in Germany but "€1,234.56"
+// in Ireland. This is a small table of the common ones, not a CLDR.
+
+package money
+
+import (
+	"strings"
+)
+
+// Locale holds the number conventions of a locale. Template places the
+// currency grapheme the same way as Currency.Template does.
+type Locale struct {
+	DecPoint string
+	Thousand string
+	Template string
+}
+
+// locales is keyed by lower case BCP 47 tag. Bare languages are there for tags
+// with a region we don't know about.
+var locales = map[string]Locale{
+	"de":    {DecPoint: ",", Thousand: ".", Template: "1 $"},
+	"de-at": {DecPoint: ",", Thousand: ".", Template: "$ 1"},
+	"de-ch": {DecPoint: ".", Thousand: "'", Template: "$ 1"},
+	"de-de": {DecPoint: ",", Thousand: ".", Template: "1 $"},
+	"en":    {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"en-au": {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"en-ca": {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"en-gb": {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"en-ie": {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"en-nz": {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"en-us": {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"es":    {DecPoint: ",", Thousand: ".", Template: "1 $"},
+	"es-es": {DecPoint: ",", Thousand: ".", Template: "1 $"},
+	"es-mx": {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"fr":    {DecPoint: ",", Thousand: " ", Template: "1 $"},
+	"fr-ca": {DecPoint: ",", Thousand: " ", Template: "1 $"},
+	"fr-ch": {DecPoint: ".", Thousand: " ", Template: "1 $"},
+	"fr-fr": {DecPoint: ",", Thousand: " ", Template: "1 $"},
+	"it":    {DecPoint: ",", Thousand: ".", Template: "1 $"},
+	"it-it": {DecPoint: ",", Thousand: ".", Template: "1 $"},
+	"ja":    {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"ja-jp": {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"nl":    {DecPoint: ",", Thousand: ".", Template: "$ 1"},
+	"nl-nl": {DecPoint: ",", Thousand: ".", Template: "$ 1"},
+	"pt":    {DecPoint: ",", Thousand: ".", Template: "$ 1"},
+	"pt-br": {DecPoint: ",", Thousand: ".", Template: "$ 1"},
+	"pt-pt": {DecPoint: ",", Thousand: " ", Template: "1 $"},
+	"sv":    {DecPoint: ",", Thousand: " ", Template: "1 $"},
+	"sv-se": {DecPoint: ",", Thousand: " ", Template: "1 $"},
+	"zh":    {DecPoint: ".", Thousand: ",", Template: "$1"},
+	"zh-cn": {DecPoint: ".", Thousand: ",", Template: "$1"},
+}
+
+// GetLocale returns the conventions for a BCP 47 tag like "de-DE". Case and
+// "_" in place of "-" don't matter. If the region isn't known, the language's
+// defaults are used, so "de-LU" gets "de".
+func GetLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.Replace(strings.TrimSpace(tag), "_", "-", -1))
+
+	if l, ok := locales[tag]; ok {
+		return l, true
+	}
+
+	if i := strings.Index(tag, "-"); i > 0 {
+		l, ok := locales[tag[:i]]
+		return l, ok
+	}
+
+	return Locale{}, false
+}
+
+// StringLocale formats m like FormattedStringBank, but with the separators
+// and symbol placement of the locale tag, rather than those of the currency.
+// The currency still decides the grapheme and the number of decimal places.
+//
+// Example:
+//
+//	eur := RequireFromString("EUR", "1234.56")
+//	eur.StringLocale("de-DE") // output: "1.234,56 €"
+//	eur.StringLocale("en-IE") // output: "€1,234.56"
+//
+// An unknown tag falls back to the currency's own formatting.
+func (m Money) StringLocale(tag string) string {
+	m.ensureInitialized()
+
+	f := m.currency.Formatter()
+	if l, ok := GetLocale(tag); ok {
+		f.DecPoint = l.DecPoint
+		f.Thousand = l.Thousand
+		f.Template = l.Template
+	}
+
+	return f.FormatCurrency(m.amount)
+}
@@ -0,0 +1,51 @@
+package money
+
+import (
+	"testing"
+)
+
+func TestGetLocale(t *testing.T) {
+	tcs := []struct {
+		tag      string
+		expected Locale
+		ok       bool
+	}{
+		{"de-DE", Locale{DecPoint: ",", Thousand: ".", Template: "1 $"}, true},
+		{"de_de", Locale{DecPoint: ",", Thousand: ".", Template: "1 $"}, true},
+		{"de-LU", Locale{DecPoint: ",", Thousand: ".", Template: "1 $"}, true},
+		{"en-IE", Locale{DecPoint: ".", Thousand: ",", Template: "$1"}, true},
+		{"xx-YY", Locale{}, false},
+		{"", Locale{}, false},
+	}
+
+	for _, tc := range tcs {
+		l, ok := GetLocale(tc.tag)
+		if ok != tc.ok || l != tc.expected {
+			t.Errorf("%q: expected %+v %v, got %+v %v", tc.tag, tc.expected, tc.ok, l, ok)
+		}
+	}
+}
+
+func TestMoney_StringLocale(t *testing.T) {
+	tcs := []struct {
+		curr     string
+		value    string
+		tag      string
+		expected string
+	}{
+		{"EUR", "1234.56", "de-DE", "1.234,56 €"},
+		{"EUR", "1234.56", "en-IE", "€1,234.56"},
+		{"EUR", "-1234.56", "de-DE", "-1.234,56 €"},
+		{"EUR", "1234567.891", "fr-FR", "1 234 567,89 €"},
+		{"CHF", "1234.5", "de-CH", "CHF 1'234.50"},
+		{"USD", "1234.56", "nl-NL", "$ 1.234,56"},
+		{"JPY", "1234", "de-DE", "1.234 ¥"},
+		{"EUR", "1234.56", "xx-YY", "€1,234.56"},
+	}
+
+	for _, tc := range tcs {
+		if got := RequireFromString(tc.curr, tc.value).StringLocale(tc.tag); got != tc.expected {
+			t.Errorf("%s %s (%s): expected %q got %q", tc.curr, tc.value, tc.tag, tc.expected, got)
+		}
+	}
+}
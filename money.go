@@ -13,10 +13,17 @@ package money
 import (
 	"database/sql/driver"
 	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"github.com/shopspring/decimal"
 	"math"
 	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // Core Monetary construct which uses shopspring's decimal number and adds a
@@ -25,6 +32,11 @@ import (
 // Note: No currency mixing is allowed. For that we'll create an exchange library.
 //  Trying to perform operations (add/subtract/compare/etc) on mixed currency Moneys
 //  will panic. YOU HAVE BEEN WARNED.
+// Note: A Money points at its currency's entry in the registry rather than
+//  holding a copy. AddCurrency swaps in a new entry, so Moneys made before it
+//  keep the old definition, but changing the fields of a *Currency you got from
+//  GetCurrency changes every Money in that currency. Use Clone if a Money must
+//  not see that.
 type Money struct {
 	amount   decimal.Decimal
 	currency *Currency
@@ -47,6 +59,66 @@ type Money struct {
 //
 var DivisionPrecision = 20
 
+// MarshalJSONWithoutQuotes should be set to true if you want the amount to be
+// marshaled as a JSON number instead of a string, ie.
+//
+//     {"amount":123.45,"currency":"USD"}
+//
+// NOTE: Most JSON consumers decode numbers into a float64, which silently loses
+// precision on large or very precise amounts. The amount is exact on the wire
+// either way, but quoting it is the only way to make sure it stays that way,
+// hence quoted is the default. Both forms are accepted when unmarshaling.
+var MarshalJSONWithoutQuotes = false
+
+// MarshalJSONFixedFraction marshals the amount with at least the currency's
+// Fraction places, ie.
+//
+//     {"amount":"1.50","currency":"USD"}
+//     {"amount":"1000","currency":"JPY"}
+//
+// Amounts finer than the Fraction keep all their digits rather than being
+// rounded, so it's still exact; it's the same form Key uses. It's on by
+// default, set it to false to trim trailing zeros instead, ie. "1.5". Either
+// way the output depends only on the value, not how it was built, which makes
+// it safe for golden files.
+var MarshalJSONFixedFraction = true
+
+// AllowUnknownCurrencyOps lets a Money in UnknownCurrencyCode be mixed with a
+// Money of any currency, with the result taking the known currency, ie.
+//
+//     var scanned Money
+//     scanned.Scan("10")          // ??? 10
+//     scanned.Add(usd)            // a USD Money, rather than a panic
+//
+// This saves calling UpdateCurrency on everything that comes out of the
+// database, but at a price: the check that stops you adding dollars to euros is
+// gone for anything that was never told its currency, so a EUR amount which was
+// scanned and not updated will happily be added to USD. Defaults to false.
+var AllowUnknownCurrencyOps = false
+
+// StrictDimensions makes Mul panic when both Moneys have a real currency, as
+// $3 times $4 isn't $12 of anything. Scale an amount by a plain quantity with
+// MulScalar or MulInt instead. A Money in UnknownCurrencyCode still counts as
+// a plain quantity, so Mul keeps working with AllowUnknownCurrencyOps.
+// Defaults to false.
+var StrictDimensions = false
+
+// ValuerMode decides what Value hands to the database. See ValueMode.
+type ValuerMode int
+
+// Valuer modes available.
+const (
+	ValueAmountOnly ValuerMode = iota //	ValueAmountOnly	(just the amount, ie. "123.45")
+	ValueComposite                    //	ValueComposite	(code and amount, ie. "USD 123.45", as MarshalText)
+)
+
+// ValueMode sets what Value stores. ValueAmountOnly, the default, suits a
+// numeric column but loses the currency, so Scan gives you UnknownCurrencyCode
+// back. ValueComposite keeps the currency and Scan reads it back in, but the
+// column has to be text (ie. varchar or text rather than numeric), and the
+// database can no longer sum or compare the amounts for you.
+var ValueMode = ValueAmountOnly
+
 // Zero constant, to make computations faster.
 var ZeroMoney = Money{amount: decimal.Zero, currency: getUnknownCurrency()}
 
@@ -55,7 +127,7 @@ func New(curr string, value int64, exp int32) (Money, error) {
 
 	c, ok := GetCurrency(curr)
 	if !ok {
-		return Money{amount: decimal.Zero, currency: getBadCurrency()}, fmt.Errorf("Currency [%s] not supported", curr)
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: curr}
 	}
 	return Money{
 		amount:   decimal.New(value, exp),
@@ -69,7 +141,7 @@ func NewFromBigInt(curr string, value *big.Int, exp int32) (Money, error) {
 
 	c, ok := GetCurrency(curr)
 	if !ok {
-		return Money{amount: decimal.Zero, currency: getBadCurrency()}, fmt.Errorf("Currency [%s] not supported", curr)
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: curr}
 	}
 
 	return Money{
@@ -80,21 +152,24 @@ func NewFromBigInt(curr string, value *big.Int, exp int32) (Money, error) {
 }
 
 // NewFromString returns a new Decimal from a string representation.
+// Scientific notation, as some spreadsheet and bank exports use, is accepted
+// too, with an upper or lower case "e" and an optional sign on the exponent.
 //
 // Example:
 //
 //     d, err := NewFromString("USD", "-123.45")
 //     d2, err := NewFromString("AUD", ".0001")
+//     d3, err := NewFromString("USD", "1.2345E+4") // 12345
 //
 func NewFromString(curr string, value string) (Money, error) {
 
 	c, ok := GetCurrency(curr)
 	if !ok {
-		return Money{amount: decimal.Zero, currency: getBadCurrency()}, fmt.Errorf("Currency [%s] not supported", curr)
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: curr}
 	}
 	d, errr := decimal.NewFromString(value)
 	if errr != nil {
-		return Money{amount: decimal.Zero, currency: getBadCurrency()}, errr
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, errr, "%s", errr)
 	}
 	return Money{
 		amount:   d,
@@ -102,6 +177,77 @@ func NewFromString(curr string, value string) (Money, error) {
 	}, nil
 }
 
+// NewFromStringStrict is NewFromString for untrusted input. It parses the same
+// way, but returns an error if value has more precision than the currency can
+// hold, rather than quietly carrying fractions of a cent into a ledger.
+// Trailing zeros don't count, so "1.9900" is fine for USD.
+//
+// Example:
+//
+//     d, err := NewFromStringStrict("USD", "1.99")       // ok
+//     d, err := NewFromStringStrict("USD", "1.005")      // error
+//     d, err := NewFromStringStrict("BTC", "0.00000001") // ok, BTC has 8 places
+//
+func NewFromStringStrict(curr string, value string) (Money, error) {
+
+	m, err := NewFromString(curr, value)
+	if err != nil {
+		return m, err
+	}
+
+	if m.HasSubMinorUnits() {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, nil, "Amount '%s' has more than %d decimal places for currency [%s]", value, m.currency.Fraction, curr)
+	}
+
+	return m, nil
+}
+
+// NewFromLocaleString returns a new Money from a string using the given
+// separators, rather than the currency's own, ie. for a CSV export whose locale
+// is known but doesn't match the currency's. A leading "-" or surrounding
+// brackets are treated as negative.
+//
+// Example:
+//
+//     d, err := NewFromLocaleString("USD", "1.234,56", ",", ".")  // 1234.56
+//     d2, err := NewFromLocaleString("EUR", "1 234,56", ",", " ") // 1234.56
+//
+// An error is returned if decPoint is empty or the same as thousand, or value
+// can't be read unambiguously with them, ie. a thousand separator after the
+// decimal point, or a group that isn't three digits ("0.5" with "." for
+// thousands). The first group may be shorter, and value needn't be grouped.
+func NewFromLocaleString(curr string, value string, decPoint string, thousand string) (Money, error) {
+
+	c, ok := GetCurrency(curr)
+	if !ok {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: curr}
+	}
+
+	if decPoint == "" || decPoint == thousand {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, nil, "Cannot parse '%s': ambiguous separators, decimal point [%s] thousands [%s]", value, decPoint, thousand)
+	}
+
+	if i := strings.Index(value, decPoint); i >= 0 {
+		if thousand != "" && strings.Contains(value[i:], thousand) {
+			return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, nil, "Cannot parse '%s': thousands separator [%s] after the decimal point [%s]", value, thousand, decPoint)
+		}
+		if strings.Count(value, decPoint) > 1 {
+			return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrParse, nil, "Cannot parse '%s': more than one decimal point [%s]", value, decPoint)
+		}
+	}
+
+	f := &Formatter{DecPoint: decPoint, Thousand: thousand, Template: "1"}
+	amount, err := f.parse(value, true)
+	if err != nil {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, err
+	}
+
+	return Money{
+		amount:   amount,
+		currency: c,
+	}, nil
+}
+
 // RequireFromString returns a new Money from a string representation
 // or panics if NewFromString would have returned an error.
 //
@@ -126,13 +272,64 @@ func RequireFromString(curr string, value string) Money {
 //     NewFromFloat("AUD", .00000000000000001).String() // output: "$0.00000000000000001"
 //
 // NOTE: some float64 numbers can take up about 300 bytes of memory in decimal representation.
-// Consider using NewFromFloatWithExponent if space is more important than precision.
+// Consider using NewFromFloatRounded (or NewFromFloatWithExponent) if space is more important than precision.
 //
 // NOTE: this will panic on NaN, +/-inf
 func NewFromFloat(curr string, value float64) (Money, error) {
 	return NewFromFloatWithExponent(curr, value, math.MinInt32)
 }
 
+// NewFromFloatRounded converts a float64 to Money, rounded straight away to the
+// currency's Fraction using DefaultRoundingMode. This is usually what you want
+// from a float, as the long binary tail NewFromFloat keeps is almost never real
+// money, and it costs memory for every Money built from it.
+//
+// The float is read as the shortest decimal that round trips (ie. 2.675 rather
+// than 2.67499999...) before rounding, so halves round as they look. The result
+// always has exactly Fraction places, so it's the safest way in for a float.
+//
+// Example:
+//
+//     NewFromFloatRounded("USD", 123.456).String()       // output: "123.46"
+//     NewFromFloatRounded("JPY", 123.456).String()       // output: "123"
+//     NewFromFloatRounded("USD", 0.1+0.2).StringFixed(2) // output: "0.30"
+//
+// NOTE: this will panic on NaN, +/-inf
+func NewFromFloatRounded(curr string, value float64) (Money, error) {
+	return newFromFloatRounded(curr, value, DefaultRoundingMode)
+}
+
+// NewFromFloatCurrencyPrecision is NewFromFloatRounded, but always with
+// banker's rounding (RoundHalfEven) whatever DefaultRoundingMode is set to.
+//
+// Example:
+//
+//     NewFromFloatCurrencyPrecision("USD", 2.665).String()         // output: "2.66"
+//     NewFromFloatCurrencyPrecision("USD", 0.1+0.2).StringFixed(2) // output: "0.30"
+//
+// NOTE: this will panic on NaN, +/-inf
+func NewFromFloatCurrencyPrecision(curr string, value float64) (Money, error) {
+	return newFromFloatRounded(curr, value, RoundHalfEven)
+}
+
+// newFromFloatRounded converts a float64 to Money, rounded to the currency's
+// Fraction using mode.
+func newFromFloatRounded(curr string, value float64, mode RoundingMode) (Money, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		panic(fmt.Sprintf("Cannot create a Decimal from %v", value))
+	}
+
+	c, ok := GetCurrency(curr)
+	if !ok {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: curr}
+	}
+
+	return Money{
+		amount:   roundDecimal(decimal.NewFromFloat(value), int32(c.Fraction), mode),
+		currency: c,
+	}, nil
+}
+
 // NewFromFloatWithExponent converts a float64 to Decimal, with an arbitrary
 // number of fractional digits.
 //
@@ -147,7 +344,7 @@ func NewFromFloatWithExponent(curr string, value float64, exp int32) (Money, err
 
 	c, ok := GetCurrency(curr)
 	if !ok {
-		return Money{amount: decimal.Zero, currency: getBadCurrency()}, fmt.Errorf("Currency [%s] not supported", curr)
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: curr}
 	}
 
 	return Money{
@@ -162,12 +359,12 @@ func NewFromFloatWithExponent(curr string, value float64, exp int32) (Money, err
 func (m *Money) UpdateCurrency(newCurr string) error {
 
 	if m.currency.Code != UnknownCurrencyCode {
-		return fmt.Errorf("Cannot change currency to [%s]. Already set to [%s]!", newCurr, m.currency.Code)
+		return newError(ErrCurrencyMismatch, nil, "Cannot change currency to [%s]. Already set to [%s]!", newCurr, m.currency.Code)
 	}
 
 	c, ok := GetCurrency(newCurr)
 	if !ok {
-		return fmt.Errorf("Currency [%s] not supported", newCurr)
+		return &UnsupportedCurrencyError{Code: newCurr}
 	}
 
 	m.currency = c
@@ -176,13 +373,53 @@ func (m *Money) UpdateCurrency(newCurr string) error {
 
 }
 
+// WithCurrency returns a copy of m relabelled as the currency with the given
+// code, ie. to fix data stored under the wrong currency. Unlike UpdateCurrency
+// it works whatever m's currency is, and leaves m itself alone.
+//
+// NOTE: This does NOT convert anything. USD 10 becomes EUR 10, not whatever
+// 10 dollars is worth in euros, and the amount isn't rounded to the new
+// currency's Fraction either. To convert between currencies, use an
+// Exchanger.
+//
+// An error is returned if code isn't a known currency.
+func (m Money) WithCurrency(code string) (Money, error) {
+
+	m.ensureInitialized()
+
+	c, ok := GetCurrency(code)
+	if !ok {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: code}
+	}
+
+	return Money{
+		amount:   m.amount,
+		currency: c,
+	}, nil
+}
+
+// Clone returns a copy of m with its own copy of the currency, so it's
+// unaffected by anything later done to the registry's *Currency. The amount is
+// immutable already, so it's shared.
+func (m Money) Clone() Money {
+
+	m.ensureInitialized()
+
+	c := *m.currency
+
+	return Money{
+		amount:   m.amount,
+		currency: &c,
+	}
+}
+
 // Abs returns the absolute value of the decimal.
 func (m Money) Abs() Money {
 
 	m.ensureInitialized()
 
 	return Money{
-		amount:   m.amount.Abs(),
+		amount:   unsignedZero(m.amount.Abs()),
 		currency: m.currency,
 	}
 }
@@ -196,13 +433,14 @@ func (m Money) Add(m2 Money) Money {
 	m.ensureInitialized()
 	m2.ensureInitialized()
 
-	if !m.currency.equals(m2.currency) {
+	c, ok := m.currencyWith(m2)
+	if !ok {
 		panic(fmt.Sprintf("Cannot add mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
 	}
 
 	return Money{
 		amount:   m.amount.Add(m2.amount),
-		currency: m.currency,
+		currency: c,
 	}
 }
 
@@ -215,7 +453,8 @@ func (m Money) Sub(m2 Money) Money {
 	m.ensureInitialized()
 	m2.ensureInitialized()
 
-	if !m.currency.equals(m2.currency) {
+	c, ok := m.currencyWith(m2)
+	if !ok {
 		panic(fmt.Sprintf("Cannot subtract mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
 	}
 
@@ -223,8 +462,60 @@ func (m Money) Sub(m2 Money) Money {
 
 	return Money{
 		amount:   m.amount.Sub(m2.amount),
-		currency: m.currency,
+		currency: c,
+	}
+}
+
+// AddMany returns m plus each of others, ie. a.AddMany(b, c, d) is
+// a.Add(b).Add(c).Add(d).
+//
+// NOTE: Like Add, this will panic if any of the currencies don't match.
+func (m Money) AddMany(others ...Money) Money {
+
+	m.ensureInitialized()
+
+	total := m
+	for _, m2 := range others {
+		m2.ensureInitialized()
+
+		c, ok := total.currencyWith(m2)
+		if !ok {
+			panic(fmt.Sprintf("Cannot add mismatched currencies m1[%s] m2[%s]", total.currency, m2.currency))
+		}
+
+		total = Money{
+			amount:   total.amount.Add(m2.amount),
+			currency: c,
+		}
+	}
+
+	return total
+}
+
+// SubMany returns m less each of others, ie. a.SubMany(b, c, d) is
+// a.Sub(b).Sub(c).Sub(d).
+//
+// NOTE: Like Sub, this will panic if any of the currencies don't match.
+func (m Money) SubMany(others ...Money) Money {
+
+	m.ensureInitialized()
+
+	total := m
+	for _, m2 := range others {
+		m2.ensureInitialized()
+
+		c, ok := total.currencyWith(m2)
+		if !ok {
+			panic(fmt.Sprintf("Cannot subtract mismatched currencies m1[%s] m2[%s]", total.currency, m2.currency))
+		}
+
+		total = Money{
+			amount:   total.amount.Sub(m2.amount),
+			currency: c,
+		}
 	}
+
+	return total
 }
 
 // Neg returns -d.
@@ -233,15 +524,64 @@ func (m Money) Neg() Money {
 	m.ensureInitialized()
 
 	return Money{
-		amount:   m.amount.Neg(),
+		amount:   unsignedZero(m.amount.Neg()),
 		currency: m.currency,
 	}
 }
 
+// SignNames are the labels SignString gives to negative, zero and positive
+// amounts.
+type SignNames struct {
+	Negative string
+	Zero     string
+	Positive string
+}
+
+// DefaultSignNames is used by SignString. Change it (or use SignStringWith) if
+// your ledger treats positive amounts as debits.
+var DefaultSignNames = SignNames{Negative: "DR", Zero: "ZERO", Positive: "CR"}
+
+// BankStatementSignNames are the labels FormatBankStatement puts after the
+// amount. Zero is left without one.
+var BankStatementSignNames = SignNames{Negative: "DR", Positive: "CR"}
+
+// SignString labels the direction of m for a ledger, ie. "DR" when negative,
+// "CR" when positive and "ZERO" otherwise, as set in DefaultSignNames. Pair it
+// with Magnitude to show the amount without its sign.
+func (m Money) SignString() string {
+	return m.SignStringWith(DefaultSignNames)
+}
+
+// SignStringWith is SignString using the given names.
+func (m Money) SignStringWith(names SignNames) string {
+	switch m.Sign() {
+	case -1:
+		return names.Negative
+	case 1:
+		return names.Positive
+	}
+	return names.Zero
+}
+
+// Magnitude returns the size of m without its sign. It's the same as Abs.
+func (m Money) Magnitude() Money {
+	return m.Abs()
+}
+
+// FlipSign returns m with its sign reversed. It's the same as Neg, but reads
+// better when moving an amount between the two sides of a ledger.
+func (m Money) FlipSign() Money {
+	return m.Neg()
+}
+
 // Mul returns d * d2.
 //
-// NOTE: This will panic if you try to multiply Moneys of differing currencies.
-// That functionality may come later
+// Money times money isn't money, so to scale an amount by a quantity, ie. a
+// price by a number of items, use MulScalar or MulInt instead. Set
+// StrictDimensions to have Mul reject two real currencies outright.
+//
+// NOTE: This will panic if you try to multiply Moneys of differing currencies,
+// or any two real currencies when StrictDimensions is set.
 //
 // NOTE: This will also panic if you manage to overflow the amount
 func (m Money) Mul(m2 Money) Money {
@@ -249,523 +589,2171 @@ func (m Money) Mul(m2 Money) Money {
 	m.ensureInitialized()
 	m2.ensureInitialized()
 
-	if !m.currency.equals(m2.currency) {
+	c, ok := m.currencyWith(m2)
+	if !ok {
 		panic(fmt.Sprintf("Cannot multiply mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
 	}
 
+	if StrictDimensions && m.currency.Code != UnknownCurrencyCode && m2.currency.Code != UnknownCurrencyCode {
+		panic(fmt.Sprintf("Cannot multiply two amounts of money m1[%s] m2[%s], use MulScalar", m.currency, m2.currency))
+	}
+
 	return Money{
 		amount:   m.amount.Mul(m2.amount),
-		currency: m.currency,
+		currency: c,
 	}
 }
 
-// Shift shifts the Money amount in base 10.
-// It shifts left when shift is positive and right if shift is negative.
-// In simpler terms, the given value for shift is added to the exponent
-// of the decimal.
-func (m Money) Shift(shift int32) Money {
+// MulScalar returns m * s, where s is a plain quantity with no currency.
+//
+// Example:
+//
+//     RequireFromString("USD", "9.99").MulScalar(decimal.New(3, 0)).String() // output: "29.97"
+//
+// NOTE: This will also panic if you manage to overflow the amount
+func (m Money) MulScalar(s decimal.Decimal) Money {
 
 	m.ensureInitialized()
 
 	return Money{
-		amount:   m.amount.Shift(shift),
+		amount:   m.amount.Mul(s),
 		currency: m.currency,
 	}
 }
 
-// DivRound divides and rounds to a given precision
-// i.e. to an integer multiple of 10^(-precision)
-//   for a positive quotient digit 5 is rounded up, away from 0
-//   if the quotient is negative then digit 5 is rounded down, away from 0
-// Note that precision<0 is allowed as input.
+// MulInt returns m * i. See MulScalar.
+func (m Money) MulInt(i int64) Money {
+	return m.MulScalar(decimal.New(i, 0))
+}
+
+// DivScalar returns m / s, where s is a plain quantity with no currency. As
+// with Div, if it doesn't divide exactly the result will have
+// DivisionPrecision digits after the decimal point, with the last digit
+// rounded half away from zero.
 //
-// NOTE: This will panic if you try to divide Moneys of differing currencies.
-// That functionality may come later
-func (m Money) DivRound(m2 Money, precision int32) Money {
+// Example:
+//
+//     RequireFromString("USD", "10.00").DivScalar(decimal.New(3, 0)).String() // output: "3.33333333333333333333"
+//
+// NOTE: This will panic if s is zero, as per the decimal package.
+func (m Money) DivScalar(s decimal.Decimal) Money {
 
 	m.ensureInitialized()
-	m2.ensureInitialized()
-
-	if !m.currency.equals(m2.currency) {
-		panic(fmt.Sprintf("Cannot divide amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
-	}
 
 	return Money{
-		amount:   m.amount.DivRound(m2.amount, precision),
+		amount:   m.amount.DivRound(s, int32(DivisionPrecision)),
 		currency: m.currency,
 	}
 }
 
-// Div returns d / d2. If it doesn't divide exactly, the result will have
-// DivisionPrecision digits after the decimal point.
-//
-// NOTE: This will panic (thrown eventually from DivRound) if you try to
-// divide Moneys of differing currencies.
-// That functionality may come later
-func (m Money) Div(m2 Money) Money {
-	return m.DivRound(m2, int32(DivisionPrecision))
+// DivInt returns m / i. See DivScalar.
+func (m Money) DivInt(i int64) Money {
+	return m.DivScalar(decimal.New(i, 0))
 }
 
-// QuoRem does divsion with remainder
-// d.QuoRem(d2,precision) returns quotient q and remainder r such that
-//   d = d2 * q + r, q an integer multiple of 10^(-precision)
-//   0 <= r < abs(d2) * 10 ^(-precision) if d>=0
-//   0 >= r > -abs(d2) * 10 ^(-precision) if d<0
-// Note that precision<0 is allowed as input.
-func (m Money) QuoRem(m2 Money, precision int32) (Money, Money) {
-	m.ensureInitialized()
-	m2.ensureInitialized()
-
-	if !m.currency.equals(m2.currency) {
-		panic(fmt.Sprintf("Cannot divide amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
-	}
+// AddRounded is Add with the result rounded to the currency's Fraction using
+// DefaultRoundingMode. The Rounded methods are for amounts you're going to
+// book; keep to Add, MulScalar and friends for intermediate results so the
+// rounding only happens once.
+//
+// NOTE: Like Add, this will panic if the currencies don't match.
+func (m Money) AddRounded(m2 Money) Money {
+	return m.Add(m2).roundToFraction(DefaultRoundingMode)
+}
 
-	d1, d2 := m.amount.QuoRem(m2.amount, precision)
+// SubRounded is Sub with the result rounded to the currency's Fraction using
+// DefaultRoundingMode. See AddRounded.
+//
+// NOTE: Like Sub, this will panic if the currencies don't match.
+func (m Money) SubRounded(m2 Money) Money {
+	return m.Sub(m2).roundToFraction(DefaultRoundingMode)
+}
 
-	return Money{
-			amount:   d1,
-			currency: m.currency,
-		},
-		Money{
-			amount:   d2,
-			currency: m2.currency,
-		}
+// MulRounded is MulScalar with the result rounded to the currency's Fraction
+// using DefaultRoundingMode.
+//
+// Example:
+//
+//     RequireFromString("USD", "2.50").MulRounded(decimal.RequireFromString("0.05")).String() // output: "0.12"
+//
+func (m Money) MulRounded(s decimal.Decimal) Money {
+	return m.MulScalar(s).roundToFraction(DefaultRoundingMode)
 }
 
-// Mod returns d % d2.
-func (m Money) Mod(m2 Money) Money {
-	m.ensureInitialized()
-	m2.ensureInitialized()
+// DivRounded is DivScalar with the result rounded to the currency's Fraction
+// using DefaultRoundingMode. The rounding is done on the exact quotient, not
+// on a DivisionPrecision approximation of it.
+//
+// Example:
+//
+//     RequireFromString("USD", "10.00").DivRounded(decimal.New(3, 0)).String() // output: "3.33"
+//
+// NOTE: The parts won't always add back up to m; use Allocate to split an
+// amount without losing any cents.
+//
+// NOTE: This will panic if s is zero, as per the decimal package.
+func (m Money) DivRounded(s decimal.Decimal) Money {
 
-	if !m.currency.equals(m2.currency) {
-		panic(fmt.Sprintf("Cannot modulo amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
-	}
+	m.ensureInitialized()
 
 	return Money{
-		amount:   m.amount.Mod(m2.amount),
+		amount:   unsignedZero(divRoundDecimal(m.amount, s, int32(m.currency.Fraction), DefaultRoundingMode)),
 		currency: m.currency,
 	}
 }
 
-// Pow returns d to the power d2
-func (m Money) Pow(m2 Money) Money {
+// Allocate splits m into parts in proportion to ratios, each rounded to the
+// currency's Fraction, such that the parts always add up to m (itself rounded
+// to the Fraction first). Any minor units left over are handed out one at a
+// time, starting with the first part.
+//
+// Example:
+//
+//     RequireFromString("USD", "10.00").Allocate(1, 1, 1) // 3.34, 3.33, 3.33
+//     RequireFromString("USD", "5.00").Allocate(70, 30)   // 3.50, 1.50
+//
+// NOTE: This will panic if there are no ratios, any ratio is negative, or they
+// add up to zero.
+func (m Money) Allocate(ratios ...int) []Money {
+	return m.AllocateWith(ratios, RemainderEarliest)
+}
+
+// RemainderStrategy decides which parts get the minor units left over when
+// Allocate can't split an amount exactly. See AllocateWith. The zero value,
+// RemainderEarliest, is what Allocate itself does.
+//
+// RemainderRoundRobin hands out one unit per part in turn, from the first. As
+// there's always less than one unit left over per part, a single call never
+// goes round twice, so it gives the same split as RemainderEarliest.
+type RemainderStrategy int
+
+// Remainder strategies available to AllocateWith.
+const (
+	RemainderEarliest         RemainderStrategy = iota //	RemainderEarliest	(one each to the first parts, the default)
+	RemainderLargestRemainder                          //	RemainderLargestRemainder	(one each to the parts that lost the most, ie. Hamilton's method)
+	RemainderLargestShare                              //	RemainderLargestShare	(all to the part with the largest ratio)
+	RemainderRoundRobin                                //	RemainderRoundRobin	(one each in turn from the first part, the same as RemainderEarliest)
+)
+
+// AllocateWith is Allocate, with strategy deciding where any minor units
+// left over go. Ties go to the earlier part.
+//
+// Example:
+//
+//     m := RequireFromString("USD", "0.10")
+//     m.AllocateWith([]int{1, 2, 4}, RemainderEarliest)         // 0.02, 0.03, 0.05
+//     m.AllocateWith([]int{1, 2, 4}, RemainderLargestRemainder) // 0.01, 0.03, 0.06
+//     m.AllocateWith([]int{1, 2, 4}, RemainderLargestShare)     // 0.01, 0.02, 0.07
+//     m.AllocateWith([]int{1, 2, 4}, RemainderRoundRobin)       // 0.02, 0.03, 0.05
+//
+// NOTE: Like Allocate, this will panic if there are no ratios, any ratio is
+// negative, or they add up to zero.
+func (m Money) AllocateWith(ratios []int, strategy RemainderStrategy) []Money {
 
 	m.ensureInitialized()
-	m2.ensureInitialized()
 
-	if !m.currency.equals(m2.currency) {
-		panic(fmt.Sprintf("Cannot take power of amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	if len(ratios) == 0 {
+		panic("Cannot allocate without any ratios")
 	}
 
-	return Money{
-		amount:   m.amount.Pow(m2.amount),
-		currency: m.currency,
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			panic(fmt.Sprintf("Cannot allocate to a negative ratio [%d]", r))
+		}
+		total += int64(r)
+	}
+
+	if total == 0 {
+		panic("Cannot allocate when the ratios add up to zero")
+	}
+
+	weights := make([]decimal.Decimal, len(ratios))
+	for i, r := range ratios {
+		weights[i] = decimal.New(int64(r), 0)
 	}
+
+	return m.allocate(weights, decimal.New(total, 0), strategy)
 }
 
-// Cmp compares the numbers represented by d and d2 and returns:
+// SplitByPercent splits m into parts by percentages, ie. 70, 20 and 10, each
+// rounded to the currency's Fraction. As with Allocate, the parts always add up
+// to m, with any minor units left over handed out one at a time from the first
+// part.
 //
-//     -1 if d <  d2
-//      0 if d == d2
-//     +1 if d >  d2
+// Example:
 //
-// NOTE: This will panic if you try to compare Moneys of differing currencies.
-// That functionality may come later
-func (m Money) Cmp(m2 Money) int {
+//     pcts := []decimal.Decimal{decimal.New(70, 0), decimal.New(20, 0), decimal.New(10, 0)}
+//     RequireFromString("USD", "100.00").SplitByPercent(pcts...) // 70.00, 20.00, 10.00
+//     RequireFromString("USD", "0.10").SplitByPercent(pcts...)   // 0.07, 0.02, 0.01
+//
+// An error is returned if there are no percentages, any are negative, or they
+// don't add up to 100 (give or take 0.000001).
+func (m Money) SplitByPercent(percents ...decimal.Decimal) ([]Money, error) {
 
 	m.ensureInitialized()
-	m2.ensureInitialized()
 
-	if !m.currency.equals(m2.currency) {
-		panic(fmt.Sprintf("Cannot compare amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	if len(percents) == 0 {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot split without any percentages")
 	}
 
-	return m.amount.Cmp(m2.amount)
-}
+	total := decimal.Zero
+	for _, p := range percents {
+		if p.Sign() < 0 {
+			return nil, newError(ErrInvalidArgument, nil, "Cannot split to a negative percentage [%s]", p)
+		}
+		total = total.Add(p)
+	}
+
+	if total.Sub(decimal.New(100, 0)).Abs().Cmp(percentEpsilon) > 0 {
+		return nil, newError(ErrInvalidArgument, nil, "Percentages must add up to 100, got [%s]", total)
+	}
+
+	return m.allocate(percents, total, RemainderEarliest), nil
+}
+
+// percentEpsilon is how far from 100 SplitByPercent lets the percentages add
+// up to, so thirds like 33.333333 are accepted.
+var percentEpsilon = decimal.New(1, -6)
+
+// allocate splits m into parts in proportion to weights, which add up to
+// total, handing out the leftover minor units by strategy. See AllocateWith.
+func (m Money) allocate(weights []decimal.Decimal, total decimal.Decimal, strategy RemainderStrategy) []Money {
+
+	places := int32(m.currency.Fraction)
+	amount := roundDecimal(m.amount, places, DefaultRoundingMode)
+
+	// Each part is truncated towards zero, so what's left has the sign of amount
+	parts := make([]Money, len(weights))
+	lost := make([]decimal.Decimal, len(weights))
+	left := amount
+	for i, w := range weights {
+		share, rem := amount.Mul(w).QuoRem(total, places)
+		parts[i] = Money{amount: share, currency: m.currency}
+		lost[i] = rem.Abs()
+		left = left.Sub(share)
+	}
+
+	unit := decimal.New(1, -places)
+	if left.Sign() < 0 {
+		unit = unit.Neg()
+	}
+
+	// The order in which the parts get a unit each
+	order := make([]int, 0, len(weights))
+	for i, w := range weights {
+		if w.Sign() != 0 {
+			order = append(order, i)
+		}
+	}
+
+	switch strategy {
+	case RemainderLargestRemainder:
+		sort.SliceStable(order, func(a, b int) bool {
+			return lost[order[a]].Cmp(lost[order[b]]) > 0
+		})
+
+	case RemainderLargestShare:
+		largest := order[0]
+		for _, i := range order {
+			if weights[i].Cmp(weights[largest]) > 0 {
+				largest = i
+			}
+		}
+		parts[largest].amount = parts[largest].amount.Add(left)
+		left = decimal.Zero
+	}
+
+	// Less than one unit was lost per non-zero weight, so one pass is enough
+	for i := 0; left.Sign() != 0; i++ {
+		parts[order[i]].amount = parts[order[i]].amount.Add(unit)
+		left = left.Sub(unit)
+	}
+
+	for i := range parts {
+		parts[i].amount = unsignedZero(parts[i].amount)
+	}
+
+	return parts
+}
+
+// Installments splits m into n equal payments, each rounded down to the
+// currency's Fraction, with whatever's left over added to just the first
+// payment (or the last, if remainderFirst is false). The payments always add
+// up to m, itself rounded to the Fraction first. Unlike Allocate, which spreads
+// the odd minor units out a unit at a time, all of them land on the one
+// payment.
+//
+// Example:
+//
+//     RequireFromString("USD", "100.00").Installments(3, true)  // 33.34, 33.33, 33.33
+//     RequireFromString("USD", "100.00").Installments(3, false) // 33.33, 33.33, 33.34
+//
+// An error is returned if n isn't positive.
+func (m Money) Installments(n int, remainderFirst bool) ([]Money, error) {
+
+	m.ensureInitialized()
+
+	if n <= 0 {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot split into [%d] installments, there must be at least one", n)
+	}
+
+	places := int32(m.currency.Fraction)
+	amount := roundDecimal(m.amount, places, DefaultRoundingMode)
+	count := decimal.New(int64(n), 0)
+
+	// Truncated towards zero, so the remainder has the sign of amount
+	each, _ := amount.QuoRem(count, places)
+	each = unsignedZero(each)
+	left := amount.Sub(each.Mul(count))
+
+	parts := make([]Money, n)
+	for i := range parts {
+		parts[i] = Money{amount: each, currency: m.currency}
+	}
+
+	i := n - 1
+	if remainderFirst {
+		i = 0
+	}
+	parts[i].amount = unsignedZero(parts[i].amount.Add(left))
+
+	return parts, nil
+}
+
+// Shift shifts the Money amount in base 10.
+// It shifts left when shift is positive and right if shift is negative.
+// In simpler terms, the given value for shift is added to the exponent
+// of the decimal.
+func (m Money) Shift(shift int32) Money {
+
+	m.ensureInitialized()
+
+	return Money{
+		amount:   m.amount.Shift(shift),
+		currency: m.currency,
+	}
+}
+
+// Apply returns fn applied to m's amount, keeping m's currency. It's a way to
+// do one-off sums the Money methods don't cover, without taking the amount out
+// and putting it back again.
+//
+// Example:
+//
+//     RequireFromString("USD", "100").Apply(func(d decimal.Decimal) decimal.Decimal {
+//         return d.Mul(decimal.RequireFromString("1.2")).Add(decimal.New(5, 0))
+//     }).String() // output: "125"
+//
+// NOTE: fn mustn't assume the amount has a particular number of decimal
+// places; 1.5 and 1.50 are both valid USD amounts, and either might be passed
+// in. Nor is the result rounded, so round it yourself if fn adds precision.
+func (m Money) Apply(fn func(decimal.Decimal) decimal.Decimal) Money {
+
+	m.ensureInitialized()
+
+	return Money{
+		amount:   unsignedZero(fn(m.amount)),
+		currency: m.currency,
+	}
+}
+
+// DecimalOp is Apply for operations that can fail, ie. a square root or a
+// logarithm. If fn returns an error, it's returned along with m unchanged.
+//
+// NOTE: Most such operations don't give an amount of money in any real sense;
+// the square root of $16 isn't $4 of anything. The result keeps m's currency
+// regardless, so making sense of it is up to you.
+func (m Money) DecimalOp(fn func(decimal.Decimal) (decimal.Decimal, error)) (Money, error) {
+
+	m.ensureInitialized()
+
+	d, err := fn(m.amount)
+	if err != nil {
+		return m, err
+	}
+
+	return Money{
+		amount:   unsignedZero(d),
+		currency: m.currency,
+	}, nil
+}
+
+// Sqrt returns the square root of m. If it isn't exact, the result will have
+// DivisionPrecision digits after the decimal point, with the last digit
+// rounded half away from zero, as with Div. See DecimalOp for why the result
+// needs care.
+//
+// An error is returned if m is negative.
+func (m Money) Sqrt() (Money, error) {
+	return m.DecimalOp(func(d decimal.Decimal) (decimal.Decimal, error) {
+		return sqrtDecimal(d, int32(DivisionPrecision))
+	})
+}
+
+// sqrtDecimal returns the square root of d to places decimal places, rounded
+// half up. It works out one digit more than needed with an integer square
+// root, which always rounds down, so the extra digit decides the rounding.
+func sqrtDecimal(d decimal.Decimal, places int32) (decimal.Decimal, error) {
+	if d.Sign() < 0 {
+		return decimal.Zero, newError(ErrInvalidArgument, nil, "Cannot take the square root of a negative amount [%s]", d)
+	}
+
+	// d * 10^(2 * (places+1)) as a whole number
+	n := d.Coefficient()
+	ten := big.NewInt(10)
+	if shift := int64(d.Exponent()) + 2*int64(places+1); shift >= 0 {
+		n.Mul(n, new(big.Int).Exp(ten, big.NewInt(shift), nil))
+	} else {
+		n.Quo(n, new(big.Int).Exp(ten, big.NewInt(-shift), nil))
+	}
+
+	root := decimal.NewFromBigInt(n.Sqrt(n), -(places + 1))
+
+	return roundDecimal(root, places, RoundHalfUp), nil
+}
+
+// DivRound divides and rounds to a given precision
+// i.e. to an integer multiple of 10^(-precision)
+//   for a positive quotient digit 5 is rounded up, away from 0
+//   if the quotient is negative then digit 5 is rounded down, away from 0
+// Note that precision<0 is allowed as input.
+//
+// NOTE: This will panic if you try to divide Moneys of differing currencies.
+// That functionality may come later
+func (m Money) DivRound(m2 Money, precision int32) Money {
+
+	m.ensureInitialized()
+	m2.ensureInitialized()
+
+	c, ok := m.currencyWith(m2)
+	if !ok {
+		panic(fmt.Sprintf("Cannot divide amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	}
+
+	return Money{
+		amount:   m.amount.DivRound(m2.amount, precision),
+		currency: c,
+	}
+}
+
+// Div returns d / d2. If it doesn't divide exactly, the result will have
+// DivisionPrecision digits after the decimal point.
+//
+// NOTE: This will panic (thrown eventually from DivRound) if you try to
+// divide Moneys of differing currencies.
+// That functionality may come later
+func (m Money) Div(m2 Money) Money {
+	return m.DivRound(m2, int32(DivisionPrecision))
+}
+
+// DivOption changes how DivOpts divides. See WithPrecision and
+// WithRoundingMode.
+type DivOption func(*divOptions)
+
+type divOptions struct {
+	precision int32
+	mode      RoundingMode
+}
+
+// WithPrecision sets the number of decimal places DivOpts rounds to, in place
+// of DivisionPrecision.
+func WithPrecision(precision int32) DivOption {
+	return func(o *divOptions) {
+		o.precision = precision
+	}
+}
+
+// WithRoundingMode sets how DivOpts rounds, in place of RoundHalfUp.
+func WithRoundingMode(mode RoundingMode) DivOption {
+	return func(o *divOptions) {
+		o.mode = mode
+	}
+}
+
+// DivOpts returns d / d2, like Div, but the precision and rounding can be set
+// per call rather than through the DivisionPrecision global, so nothing else
+// can change the result from under you.
+//
+// Example:
+//
+//     total.DivOpts(count, WithPrecision(2), WithRoundingMode(RoundHalfEven))
+//
+// Without options it behaves the same as Div: DivisionPrecision places,
+// rounding half away from zero.
+//
+// NOTE: This will panic if you try to divide Moneys of differing currencies,
+// or divide by zero.
+func (m Money) DivOpts(m2 Money, opts ...DivOption) Money {
+
+	m.ensureInitialized()
+	m2.ensureInitialized()
+
+	c, ok := m.currencyWith(m2)
+	if !ok {
+		panic(fmt.Sprintf("Cannot divide amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	}
+
+	o := divOptions{
+		precision: int32(DivisionPrecision),
+		mode:      RoundHalfUp,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return Money{
+		amount:   unsignedZero(divRoundDecimal(m.amount, m2.amount, o.precision, o.mode)),
+		currency: c,
+	}
+}
+
+// QuoRem does divsion with remainder
+// d.QuoRem(d2,precision) returns quotient q and remainder r such that
+//   d = d2 * q + r, q an integer multiple of 10^(-precision)
+//   0 <= r < abs(d2) * 10 ^(-precision) if d>=0
+//   0 >= r > -abs(d2) * 10 ^(-precision) if d<0
+// Note that precision<0 is allowed as input.
+func (m Money) QuoRem(m2 Money, precision int32) (Money, Money) {
+	m.ensureInitialized()
+	m2.ensureInitialized()
+
+	c, ok := m.currencyWith(m2)
+	if !ok {
+		panic(fmt.Sprintf("Cannot divide amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	}
+
+	d1, d2 := m.amount.QuoRem(m2.amount, precision)
+
+	return Money{
+			amount:   d1,
+			currency: c,
+		},
+		Money{
+			amount:   d2,
+			currency: c,
+		}
+}
+
+// DivMod returns how many whole divisors fit into m, and what's left over, ie.
+// how many $5 notes make up $23 and the change. It's QuoRem to a precision of
+// 0, with the count as an int64. As with QuoRem, the count is truncated
+// towards zero and the remainder has the sign of m:
+//
+//     $23.00 DivMod $5.00   // 4, $3.00
+//     -$23.00 DivMod $5.00  // -4, -$3.00
+//     $23.00 DivMod -$5.00  // -4, $3.00
+//
+// An error is returned if the currencies differ, divisor is zero, or the
+// count doesn't fit in an int64.
+func (m Money) DivMod(divisor Money) (quotient int64, remainder Money, err error) {
+	m.ensureInitialized()
+	divisor.ensureInitialized()
+
+	c, ok := m.currencyWith(divisor)
+	if !ok {
+		return 0, m, newError(ErrCurrencyMismatch, nil, "Cannot divide amounts with mismatched currencies m1[%s] m2[%s]", m.currency, divisor.currency)
+	}
+
+	if divisor.amount.Sign() == 0 {
+		return 0, m, newError(ErrInvalidArgument, nil, "Cannot divide by a zero amount")
+	}
+
+	q, r := m.amount.QuoRem(divisor.amount, 0)
+	if q.Cmp(decimal.New(math.MaxInt64, 0)) > 0 || q.Cmp(decimal.New(math.MinInt64, 0)) < 0 {
+		return 0, m, newError(ErrInvalidArgument, nil, "Cannot divide [%s] by [%s], the result [%s] is too big", m.amount, divisor.amount, q)
+	}
+
+	return q.IntPart(), Money{amount: unsignedZero(r), currency: c}, nil
+}
+
+// Mod returns d % d2.
+func (m Money) Mod(m2 Money) Money {
+	m.ensureInitialized()
+	m2.ensureInitialized()
+
+	c, ok := m.currencyWith(m2)
+	if !ok {
+		panic(fmt.Sprintf("Cannot modulo amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	}
+
+	return Money{
+		amount:   m.amount.Mod(m2.amount),
+		currency: c,
+	}
+}
+
+// Pow returns d to the power d2
+func (m Money) Pow(m2 Money) Money {
+
+	m.ensureInitialized()
+	m2.ensureInitialized()
+
+	c, ok := m.currencyWith(m2)
+	if !ok {
+		panic(fmt.Sprintf("Cannot take power of amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	}
+
+	return Money{
+		amount:   m.amount.Pow(m2.amount),
+		currency: c,
+	}
+}
+
+// Cmp compares the numbers represented by d and d2 and returns:
+//
+//     -1 if d <  d2
+//      0 if d == d2
+//     +1 if d >  d2
+//
+// NOTE: This will panic if you try to compare Moneys of differing currencies.
+// That functionality may come later
+func (m Money) Cmp(m2 Money) int {
+
+	m.ensureInitialized()
+	m2.ensureInitialized()
+
+	_, ok := m.currencyWith(m2)
+	if !ok {
+		panic(fmt.Sprintf("Cannot compare amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	}
+
+	return m.amount.Cmp(m2.amount)
+}
+
+// CmpAbs compares the sizes of m and m2, ignoring their signs, ie. -10 is
+// bigger than 5 and -5 is the same size as 5:
+//
+//     -1 if |m| <  |m2|
+//      0 if |m| == |m2|
+//     +1 if |m| >  |m2|
+//
+// NOTE: Like Cmp, this will panic if the currencies don't match.
+func (m Money) CmpAbs(m2 Money) int {
+
+	m.ensureInitialized()
+	m2.ensureInitialized()
+
+	_, ok := m.currencyWith(m2)
+	if !ok {
+		panic(fmt.Sprintf("Cannot compare amounts with mismatched currencies m1[%s] m2[%s]", m.currency, m2.currency))
+	}
+
+	return m.amount.Abs().Cmp(m2.amount.Abs())
+}
 
 // Equal returns whether the numbers represented by d and d2 are equal.
 func (m Money) Equal(m2 Money) bool {
 	return m.Cmp(m2) == 0
 }
 
-// Equals is deprecated, please use Equal method instead
-func (m Money) Equals(m2 Money) bool {
-	return m.Equal(m2)
+// EqualOptions changes how EqualWith treats Moneys of different currencies.
+type EqualOptions struct {
+	// TreatZeroAsEqualAcrossCurrencies makes zero equal to zero whatever the
+	// currency, ie. USD 0 equals EUR 0.
+	TreatZeroAsEqualAcrossCurrencies bool
+}
+
+// EqualWith returns whether m and m2 are equal, like Equal, except that
+// Moneys of different currencies are never equal rather than a panic. With
+// TreatZeroAsEqualAcrossCurrencies set, two zeros are equal whatever their
+// currencies.
+//
+// Example:
+//
+//     usd, _ := New("USD", 0, 2)
+//     eur, _ := New("EUR", 0, 2)
+//     usd.EqualWith(eur, EqualOptions{})                                       // output: false
+//     usd.EqualWith(eur, EqualOptions{TreatZeroAsEqualAcrossCurrencies: true}) // output: true
+func (m Money) EqualWith(m2 Money, opts EqualOptions) bool {
+
+	m.ensureInitialized()
+	m2.ensureInitialized()
+
+	if _, ok := m.currencyWith(m2); ok {
+		return m.amount.Equal(m2.amount)
+	}
+
+	return opts.TreatZeroAsEqualAcrossCurrencies && m.amount.Sign() == 0 && m2.amount.Sign() == 0
+}
+
+// Equals is deprecated, please use Equal method instead
+func (m Money) Equals(m2 Money) bool {
+	return m.Equal(m2)
+}
+
+// GreaterThan (GT) returns true when d is greater than d2.
+func (m Money) GreaterThan(m2 Money) bool {
+	return m.Cmp(m2) == 1
+}
+
+// GreaterThanOrEqual (GTE) returns true when d is greater than or equal to d2.
+func (m Money) GreaterThanOrEqual(m2 Money) bool {
+	cmp := m.Cmp(m2)
+	return cmp == 1 || cmp == 0
+}
+
+// LessThan (LT) returns true when d is less than d2.
+func (m Money) LessThan(m2 Money) bool {
+	return m.Cmp(m2) == -1
+}
+
+// LessThanOrEqual (LTE) returns true when d is less than or equal to d2.
+func (m Money) LessThanOrEqual(m2 Money) bool {
+	cmp := m.Cmp(m2)
+	return cmp == -1 || cmp == 0
+}
+
+// cmpAmount compares m with the amount s, parsed as if it were in m's currency.
+func (m Money) cmpAmount(s string) (int, error) {
+	m.ensureInitialized()
+
+	d, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return 0, newError(ErrParse, err, "Cannot compare [%s] with '%s': %s", m.currency, s, err)
+	}
+
+	return m.amount.Cmp(d), nil
+}
+
+// EqualAmount returns whether m is equal to the amount s, in m's currency. It
+// saves building a Money just to compare against a threshold, ie.
+//
+//     over, err := total.GreaterThanAmount("100.00")
+//
+// An error is returned if s isn't a valid amount. The same goes for the other
+// ...Amount methods below.
+func (m Money) EqualAmount(s string) (bool, error) {
+	cmp, err := m.cmpAmount(s)
+	return err == nil && cmp == 0, err
+}
+
+// GreaterThanAmount returns true when m is greater than the amount s.
+func (m Money) GreaterThanAmount(s string) (bool, error) {
+	cmp, err := m.cmpAmount(s)
+	return err == nil && cmp > 0, err
+}
+
+// GreaterThanOrEqualAmount returns true when m is greater than or equal to the
+// amount s.
+func (m Money) GreaterThanOrEqualAmount(s string) (bool, error) {
+	cmp, err := m.cmpAmount(s)
+	return err == nil && cmp >= 0, err
+}
+
+// LessThanAmount returns true when m is less than the amount s.
+func (m Money) LessThanAmount(s string) (bool, error) {
+	cmp, err := m.cmpAmount(s)
+	return err == nil && cmp < 0, err
+}
+
+// LessThanOrEqualAmount returns true when m is less than or equal to the
+// amount s.
+func (m Money) LessThanOrEqualAmount(s string) (bool, error) {
+	cmp, err := m.cmpAmount(s)
+	return err == nil && cmp <= 0, err
+}
+
+// Ordering is the result of Compare. Its values line up with Cmp's, so
+// int(m.Compare(m2)) == m.Cmp(m2).
+type Ordering int
+
+// Orderings returned by Compare.
+const (
+	Less    Ordering = -1
+	Equal   Ordering = 0
+	Greater Ordering = 1
+)
+
+// String returns the name of the Ordering, ie. "Less"
+func (o Ordering) String() string {
+	switch o {
+	case Less:
+		return "Less"
+	case Equal:
+		return "Equal"
+	case Greater:
+		return "Greater"
+	}
+	return fmt.Sprintf("Ordering(%d)", int(o))
+}
+
+// Compare is Cmp with a named result, which reads better in a switch:
+//
+//     switch price.Compare(budget) {
+//     case money.Less:
+//         ...
+//     case money.Greater:
+//         ...
+//     }
+//
+// NOTE: Like Cmp, this will panic if you try to compare Moneys of differing
+// currencies.
+func (m Money) Compare(m2 Money) Ordering {
+	return Ordering(m.Cmp(m2))
+}
+
+// Min returns the smaller of m and m2. If they're equal, m is returned.
+//
+// NOTE: This will panic if you try to compare Moneys of differing currencies.
+func (m Money) Min(m2 Money) Money {
+	if m2.Cmp(m) < 0 {
+		return m2
+	}
+	return m
+}
+
+// Max returns the larger of m and m2. If they're equal, m is returned.
+//
+// NOTE: This will panic if you try to compare Moneys of differing currencies.
+func (m Money) Max(m2 Money) Money {
+	if m2.Cmp(m) > 0 {
+		return m2
+	}
+	return m
+}
+
+// Clamp returns m limited to the range lo to hi, inclusive.
+//
+// Example:
+//
+//     price.Clamp(minPrice, maxPrice)
+//
+// NOTE: This will panic if lo is greater than hi, or if the currencies of m,
+// lo and hi don't all match.
+func (m Money) Clamp(lo, hi Money) Money {
+
+	if lo.Cmp(hi) > 0 {
+		panic(fmt.Sprintf("Cannot clamp to a range with lo[%s] greater than hi[%s]", lo, hi))
+	}
+
+	return m.Max(lo).Min(hi)
+}
+
+// Delta returns the signed difference m - prev, ie. how much m has moved
+// since prev.
+//
+// NOTE: This will panic if you try to compare Moneys of differing currencies.
+func (m Money) Delta(prev Money) Money {
+	return m.Sub(prev)
+}
+
+// PercentChange returns the percentage change from prev to m, calculated as
+// (m - prev) / prev * 100. The result is dimensionless, so it's returned as a
+// plain decimal rather than a Money. If it doesn't divide exactly the result
+// will have DivisionPrecision digits after the decimal point.
+//
+// Example:
+//
+//     prev := RequireFromString("USD", "80")
+//     RequireFromString("USD", "100").PercentChange(prev) // output: 25
+//
+// An error is returned if the currencies differ, or if prev is zero.
+func (m Money) PercentChange(prev Money) (decimal.Decimal, error) {
+
+	m.ensureInitialized()
+	prev.ensureInitialized()
+
+	_, ok := m.currencyWith(prev)
+	if !ok {
+		return decimal.Zero, newError(ErrCurrencyMismatch, nil, "Cannot calculate change between mismatched currencies m1[%s] m2[%s]", m.currency, prev.currency)
+	}
+
+	if prev.amount.Sign() == 0 {
+		return decimal.Zero, newError(ErrInvalidArgument, nil, "Cannot calculate change from a zero amount")
+	}
+
+	return m.amount.Sub(prev.amount).Shift(2).DivRound(prev.amount, int32(DivisionPrecision)), nil
+}
+
+// Percent returns percent% of m, ie. 15 for 15%. It's exact, so isn't rounded
+// to the currency's Fraction.
+//
+// Example:
+//
+//     RequireFromString("USD", "80.00").Percent(ParsePercentMust("15%")).String()  // output: "12"
+//     RequireFromString("USD", "9.99").Percent(ParsePercentMust("-2.5%")).String() // output: "-0.24975"
+//
+func (m Money) Percent(percent decimal.Decimal) Money {
+	m.ensureInitialized()
+
+	return Money{
+		amount:   unsignedZero(m.amount.Mul(percent).Shift(-2)),
+		currency: m.currency,
+	}
+}
+
+// ParsePercent reads a percentage like "15%", "-2.5%" or "0.75 %", returning
+// the number of percent, so 15 for "15%", to suit Percent, SplitByPercent and
+// NewBreakdown. The % sign is optional.
+//
+// An ErrParse error is returned if s isn't a plain number, ie. "15%%", "1,5%"
+// or "%".
+func ParsePercent(s string) (decimal.Decimal, error) {
+	str := strings.TrimSpace(s)
+	str = strings.TrimSpace(strings.TrimSuffix(str, "%"))
+
+	d, err := decimal.NewFromString(str)
+	if err != nil {
+		return decimal.Zero, newError(ErrParse, err, "Cannot parse percentage '%s': %s", s, err)
+	}
+
+	return d, nil
+}
+
+// ParsePercentMust is ParsePercent, but panics if s can't be parsed, like
+// RequireFromString. Handy for constants.
+func ParsePercentMust(s string) decimal.Decimal {
+	d, err := ParsePercent(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Sign returns:
+//
+//	-1 if d <  0
+//	 0 if d == 0
+//	+1 if d >  0
+//
+func (m Money) Sign() int {
+	m.ensureInitialized()
+	return m.amount.Sign()
+}
+
+// IsSameSign returns true if m and m2 are both positive, both negative, or
+// both zero. Only the signs matter, so the currencies don't have to match.
+func (m Money) IsSameSign(m2 Money) bool {
+	return m.Sign() == m2.Sign()
+}
+
+// CmpZero compares m with zero in its own currency, so there's no need to make
+// one to pass to Cmp. It returns the same as Sign:
+//
+//	-1 if m <  0
+//	 0 if m == 0
+//	+1 if m >  0
+//
+func (m Money) CmpZero() int {
+	return m.Sign()
+}
+
+// GreaterThanZero returns true when m is positive.
+func (m Money) GreaterThanZero() bool {
+	return m.Sign() > 0
+}
+
+// LessThanZero returns true when m is negative.
+func (m Money) LessThanZero() bool {
+	return m.Sign() < 0
+}
+
+// Valid returns true when m has a real, registered currency, as opposed to
+// the zero value Money{}, UnknownCurrencyCode, or the placeholder returned
+// alongside an error.
+func (m Money) Valid() bool {
+	if m.currency == nil {
+		return false
+	}
+
+	switch m.currency.Code {
+	case UnknownCurrencyCode, BadCurrencyCode:
+		return false
+	}
+
+	_, ok := GetCurrency(m.currency.Code)
+	return ok
+}
+
+// IsInteger returns true when m is a whole number of major units, ie. $1.00
+// but not $1.50.
+func (m Money) IsInteger() bool {
+	m.ensureInitialized()
+	return m.amount.Equal(m.amount.Truncate(0))
+}
+
+// HasFraction returns true when m isn't a whole number of major units, ie.
+// $1.50 but not $1.00.
+func (m Money) HasFraction() bool {
+	return !m.IsInteger()
+}
+
+// HasSubMinorUnits returns true when m has digits finer than its currency's
+// Fraction, ie. $1.005. Trailing zeros don't count, so $1.500 doesn't.
+func (m Money) HasSubMinorUnits() bool {
+	m.ensureInitialized()
+	return !m.amount.Equal(m.amount.Truncate(int32(m.currency.Fraction)))
+}
+
+// FitsCurrencyScale returns true when m's exponent is no finer than the
+// currency's minor units, ie. it can go in a numeric(x, 2) column for USD as
+// it is. Unlike HasSubMinorUnits it looks at the scale, not the value, so
+// trailing zeros count: $1.99 fits, $1.990 and $1.999 don't.
+func (m Money) FitsCurrencyScale() bool {
+	m.ensureInitialized()
+	return m.amount.Exponent() >= -int32(m.currency.Fraction)
+}
+
+// EnsureCurrencyScale returns m, and an error if it doesn't fit the currency's
+// minor units. See FitsCurrencyScale.
+//
+// Example:
+//
+//     m, err := RequireFromString("USD", "1.999").EnsureCurrencyScale()
+//     if err != nil {
+//         // Round it first, rather than let the database truncate it
+//     }
+//
+func (m Money) EnsureCurrencyScale() (Money, error) {
+	m.ensureInitialized()
+
+	if !m.FitsCurrencyScale() {
+		return m, newError(ErrInvalidArgument, nil, "Cannot fit [%s] into %d decimal places for currency [%s]", m.amount, m.currency.Fraction, m.currency.Code)
+	}
+
+	return m, nil
+}
+
+// MustFitCurrencyScale is EnsureCurrencyScale, but panics if m doesn't fit
+// the currency's minor units.
+func (m Money) MustFitCurrencyScale() Money {
+	m.ensureInitialized()
+
+	if !m.FitsCurrencyScale() {
+		panic(fmt.Sprintf("Cannot fit [%s] into %d decimal places for currency [%s]", m.amount, m.currency.Fraction, m.currency.Code))
+	}
+
+	return m
+}
+
+// Exponent returns the exponent, or scale component of the decimal.
+func (m Money) Exponent() int32 {
+	m.ensureInitialized()
+	return m.amount.Exponent()
+}
+
+// Coefficient returns the coefficient of the decimal.  It is scaled by 10^Exponent()
+func (m Money) Coefficient() *big.Int {
+	// we copy the coefficient so that mutating the result does not mutate the
+	// Decimal.
+	m.ensureInitialized()
+
+	return m.amount.Coefficient()
+}
+
+// coefficientInt64 returns d's coefficient, and whether it fits an int64,
+// without allocating as Coefficient does. It only answers for coefficients
+// below 10^15; anything bigger reports false, and is left to Coefficient.
+func coefficientInt64(d decimal.Decimal) (int64, bool) {
+	// CoefficientInt64 wraps around for big values, NumDigits doesn't, and it
+	// doesn't allocate either when the coefficient really is this small
+	v := d.CoefficientInt64()
+	if v <= -1e15 || v >= 1e15 || d.NumDigits() > 15 {
+		return 0, false
+	}
+	return v, true
+}
+
+// DebugString returns m's internal representation as well as its value, ie.
+//
+//     Money{coefficient:12345, exp:-2, currency:USD} = 123.45
+//
+// Two amounts can print the same and still differ underneath, ie. 1.5 and
+// 1.50, which this makes plain when tracking down precision problems.
+func (m Money) DebugString() string {
+	m.ensureInitialized()
+
+	return fmt.Sprintf("Money{coefficient:%s, exp:%d, currency:%s} = %s", m.amount.Coefficient(), m.amount.Exponent(), m.currency.Code, m.String())
+}
+
+// IntPart returns the integer component of the decimal.
+func (m Money) IntPart() int64 {
+	m.ensureInitialized()
+	return m.amount.IntPart()
+}
+
+// FracPart returns the fractional component of the decimal, ie. the amount
+// less IntPart. It carries the same sign as the amount, so -12.99 splits into
+// an IntPart of -12 and a FracPart of -0.99, and IntPart + FracPart is always
+// the amount exactly.
+func (m Money) FracPart() decimal.Decimal {
+	m.ensureInitialized()
+	return unsignedZero(m.amount.Sub(m.amount.Truncate(0)))
+}
+
+// Rat returns a rational number representation of the decimal.
+func (m Money) Rat() *big.Rat {
+	m.ensureInitialized()
+	return m.amount.Rat()
+}
+
+// RatString returns the amount as a fraction in lowest terms, ie. "1/4" for
+// 0.25, or just "3" for a whole number. See big.Rat.RatString.
+func (m Money) RatString() string {
+	return m.Rat().RatString()
+}
+
+// RatExact returns Rat, and false if the amount uses all DivisionPrecision
+// decimal places, as a best guess at whether it was cut short by a division.
+// It's only a guess: an amount parsed with that many places is reported as
+// inexact too, and the answer changes if DivisionPrecision does.
+//
+// Example:
+//
+//     RequireFromString("USD", "0.25").RatExact() // output: 1/4, true
+//
+//     third := RequireFromString("USD", "1").Div(RequireFromString("USD", "3"))
+//     third.RatExact() // output: 33333333333333333333/100000000000000000000, false
+//
+// NOTE: Converting the amount to a Rat is always exact, as a Money is always
+// a decimal. A division that doesn't terminate, like 1/3, has already been
+// rounded, and can't be turned back into 1/3.
+func (m Money) RatExact() (*big.Rat, bool) {
+	m.ensureInitialized()
+	return m.Rat(), m.amount.Equal(m.amount.Truncate(int32(DivisionPrecision) - 1))
+}
+
+// Float64 returns the nearest float64 value for d and a bool indicating
+// whether f represents d exactly.
+// For more details, see the documentation for big.Rat.Float64
+func (m Money) Float64() (f float64, exact bool) {
+	m.ensureInitialized()
+	return m.amount.Float64()
+}
+
+// Float32 returns the nearest float32 value for d and a bool indicating
+// whether f represents d exactly. It rounds straight from the decimal, so it's
+// more accurate than converting the result of Float64.
+//
+// NOTE: A float32 only holds about 7 significant digits, so anything much
+// past 100000.00 loses its cents, and most amounts with cents aren't exact at
+// all (0.10 isn't). Only use it for display, never for sums or comparisons.
+// For more details, see the documentation for big.Rat.Float32
+func (m Money) Float32() (f float32, exact bool) {
+	m.ensureInitialized()
+	return m.Rat().Float32()
+}
+
+// String returns a simple string representation of the decimal
+// with the fixed point.
+// Note: It does not pretty-print the amount with currency symbols or
+//   thousand separators. Use the CurrencyStringX functions if that's what you need.
+// Example:
+//
+//     d := New("AUD",-12345, -3)
+//     println(d.String())
+//
+// Output:
+//
+//     -12.345
+//
+// Any amount equal to zero, including the zero value Money{}, prints as "0",
+// without a sign or exponent, regardless of how it was constructed.
+func (m Money) String() string {
+	m.ensureInitialized()
+
+	if m.amount.Sign() == 0 {
+		return "0"
+	}
+	return m.amount.String()
+}
+
+// Format implements the fmt.Formatter interface, so Moneys print sensibly with
+// Printf and friends:
+//
+//     %s, %v   the amount, as String, ie. "123.45"
+//     %+v, %q  the code and amount, as MarshalText, quoted for %q
+//     %#v      Go syntax, ie. money.RequireFromString("USD", "123.45")
+//
+// A precision sets the number of decimal places shown, banker rounded, so
+// Printf("%.2s", m) prints "123.40" for 123.4. A width pads on the left, or
+// on the right with the '-' flag. Any other verb is reported as bad, the same
+// way fmt does.
+func (m Money) Format(f fmt.State, verb rune) {
+	m.ensureInitialized()
+
+	amount := m.String()
+	if p, ok := f.Precision(); ok {
+		amount = m.amount.StringFixedBank(int32(p))
+	}
+
+	withCode := amount
+	if m.currency.Code != UnknownCurrencyCode {
+		withCode = m.currency.Code + " " + amount
+	}
+
+	var str string
+	switch {
+	case verb == 's' || verb == 'v' && !f.Flag('+') && !f.Flag('#'):
+		str = amount
+	case verb == 'v' && f.Flag('#'):
+		str = fmt.Sprintf("money.RequireFromString(%q, %q)", m.currency.Code, amount)
+	case verb == 'v':
+		str = withCode
+	case verb == 'q':
+		str = strconv.Quote(withCode)
+	default:
+		fmt.Fprintf(f, "%%!%c(money.Money=%s)", verb, m.String())
+		return
+	}
+
+	if w, ok := f.Width(); ok {
+		if n := utf8.RuneCountInString(str); f.Flag('-') && n < w {
+			str += strings.Repeat(" ", w-n)
+		} else {
+			str = padLeft(str, w)
+		}
+	}
+
+	fmt.Fprint(f, str)
+}
+
+// StringFixed returns a rounded fixed-point string with places digits after
+// the decimal point.
+//
+// Example:
+//
+// 	   NewFromFloat(0).StringFixed(2) // output: "0.00"
+// 	   NewFromFloat(0).StringFixed(0) // output: "0"
+// 	   NewFromFloat(5.45).StringFixed(0) // output: "5"
+// 	   NewFromFloat(5.45).StringFixed(1) // output: "5.5"
+// 	   NewFromFloat(5.45).StringFixed(2) // output: "5.45"
+// 	   NewFromFloat(5.45).StringFixed(3) // output: "5.450"
+// 	   NewFromFloat(545).StringFixed(-1) // output: "550"
+//
+// Amounts which round to zero print without a sign, ie. "0.00" not "-0.00".
+func (m Money) StringFixed(places int32) string {
+	m.ensureInitialized()
+
+	return m.amount.StringFixed(places)
+}
+
+// StringFixedBank returns a banker rounded fixed-point string with places digits
+// after the decimal point.
+//
+// Example:
+//
+// 	   NewFromFloat(0).StringFixed(2) // output: "0.00"
+// 	   NewFromFloat(0).StringFixed(0) // output: "0"
+// 	   NewFromFloat(5.45).StringFixed(0) // output: "5"
+// 	   NewFromFloat(5.45).StringFixed(1) // output: "5.4"
+// 	   NewFromFloat(5.45).StringFixed(2) // output: "5.45"
+// 	   NewFromFloat(5.45).StringFixed(3) // output: "5.450"
+// 	   NewFromFloat(545).StringFixed(-1) // output: "550"
+//
+func (m Money) StringFixedBank(places int32) string {
+	m.ensureInitialized()
+
+	return m.amount.StringFixedBank(places)
+}
+
+// AmountString returns the amount banker rounded to exactly the currency's
+// Fraction places, without grapheme, template or grouping. This is the form
+// you want in a CSV or a numeric database column.
+//
+// Example:
+//
+//     RequireFromString("USD", "1.5").AmountString()  // output: "1.50"
+//     RequireFromString("JPY", "1234").AmountString() // output: "1234"
+//
+func (m Money) AmountString() string {
+	m.ensureInitialized()
+
+	return m.StringFixedBank(int32(m.currency.Fraction))
+}
+
+func (m Money) StringFixedCash(interval uint8) string {
+	return m.RoundCash(interval).amount.StringFixed(2)
+}
+
+// Key returns a canonical "CODE:amount" string, suitable for use as a map key or
+// for set membership, since Money itself isn't comparable. The amount is written
+// to the currency's Fraction, or further if it's more precise than that, so
+// equal Moneys always produce equal keys.
+//
+// Example:
+//
+//     RequireFromString("USD", "1.5").Key()   // output: "USD:1.50"
+//     RequireFromString("USD", "1.50").Key()  // output: "USD:1.50"
+//     RequireFromString("USD", "1.005").Key() // output: "USD:1.005"
+//
+func (m Money) Key() string {
+	c := m.Canonical()
+	return c.Code + ":" + c.Amount
+}
+
+// Canonical is a comparable form of Money, so it can be used as a map key, in
+// sets, or with generics that need a comparable type. Amount is written the same
+// way as in Key, ie. to the currency's Fraction or further if the amount is more
+// precise than that, so equal Moneys always give equal Canonicals.
+type Canonical struct {
+	Code   string
+	Amount string
+}
+
+// Canonical returns the comparable form of the Money.
+func (m Money) Canonical() Canonical {
+	m.ensureInitialized()
+
+	return Canonical{
+		Code:   m.currency.Code,
+		Amount: m.canonicalAmount(),
+	}
+}
+
+// Money turns the Canonical back into a Money.
+func (c Canonical) Money() (Money, error) {
+	return NewFromString(c.Code, c.Amount)
+}
+
+// canonicalAmount returns the amount written to the currency's Fraction, or to
+// as many places as it needs if it's more precise than that.
+func (m Money) canonicalAmount() string {
+	places := int32(m.currency.Fraction)
+
+	str := m.amount.String()
+	if i := strings.IndexByte(str, '.'); i >= 0 && int32(len(str)-i-1) > places {
+		places = int32(len(str) - i - 1)
+	}
+
+	return m.amount.StringFixed(places)
+}
+
+// StringUnits returns the amount spelled out in the currency's units, rounded
+// to the currency's Fraction using DefaultRoundingMode. Useful for screen
+// readers and check writing.
+//
+// Example:
+//
+//     RequireFromString("USD", "12.99").StringUnits() // output: "12 dollars and 99 cents"
+//     RequireFromString("USD", "1.01").StringUnits() // output: "1 dollar and 1 cent"
+//     RequireFromString("USD", "-0.5").StringUnits() // output: "minus 0 dollars and 50 cents"
+//     RequireFromString("JPY", "1234").StringUnits() // output: "1234 yen"
+//
+// Currencies without unit names (ie. crypto) fall back to the fixed amount
+// followed by the code, ie. "1.50000000 BTC".
+func (m Money) StringUnits() string {
+	m.ensureInitialized()
+
+	rounded := m.roundToFraction(DefaultRoundingMode)
+	numBits := strings.Split(rounded.amount.Abs().StringFixed(int32(m.currency.Fraction)), ".")
+
+	var str string
+	switch {
+	case m.currency.MajorUnitName == "":
+		str = strings.Join(numBits, ".") + " " + m.currency.Code
+
+	case len(numBits) == 1 || m.currency.MinorUnitName == "":
+		str = numBits[0] + " " + unitName(m.currency.MajorUnitName, numBits[0])
+
+	default:
+		minor := strings.TrimLeft(numBits[1], "0")
+		if minor == "" {
+			minor = "0"
+		}
+		str = numBits[0] + " " + unitName(m.currency.MajorUnitName, numBits[0]) +
+			" and " + minor + " " + unitName(m.currency.MinorUnitName, minor)
+	}
+
+	if rounded.amount.Sign() < 0 {
+		str = "minus " + str
+	}
+
+	return str
+}
+
+// StringFixedBank returns a banker rounded fixed-point string with places digits
+// after the decimal point.
+//
+// Example:
+//
+// 	   NewFromFloat(0).StringFixed(2) // output: "0.00"
+// 	   NewFromFloat(0).StringFixed(0) // output: "0"
+// 	   NewFromFloat(5.45).StringFixed(0) // output: "5"
+// 	   NewFromFloat(5.45).StringFixed(1) // output: "5.4"
+// 	   NewFromFloat(5.45).StringFixed(2) // output: "5.45"
+// 	   NewFromFloat(5.45).StringFixed(3) // output: "5.450"
+// 	   NewFromFloat(545).StringFixed(-1) // output: "550"
+//
+func (m Money) FormattedStringBank() string {
+	m.ensureInitialized()
+
+	return m.currency.Formatter().FormatCurrency(m.amount)
+}
+
+// FormattedStringMode is FormattedStringBank, but rounded for display with
+// mode, ie. FormattedStringMode(DefaultRoundingMode) to show amounts the way
+// the package rounds them.
+//
+// Example:
+//
+//     RequireFromString("USD", "2.125").FormattedStringMode(RoundHalfEven) // output: "$2.12"
+//     RequireFromString("USD", "2.125").FormattedStringMode(RoundHalfUp)   // output: "$2.13"
+//
+func (m Money) FormattedStringMode(mode RoundingMode) string {
+	m.ensureInitialized()
+
+	return m.currency.Formatter().FormatCurrencyMode(m.amount, mode)
+}
+
+// FormatParts returns the pieces FormattedStringBank is built from, so they
+// can be put together with markup of your own, ie. the cents in superscript:
+//
+//     sign, symbol, integer, fraction := RequireFromString("USD", "-1234.56").FormatParts()
+//     // "-", "$", "1,234", "56"
+//
+// The amount is banker rounded to the currency's Fraction, as for
+// FormattedStringBank. sign is "-" or "", and is "" for anything that rounds to
+// zero. integer is grouped as the currency groups it, and fraction is padded to
+// the currency's Fraction digits, so it's "" for currencies without minor
+// units, ie. JPY.
+func (m Money) FormatParts() (sign string, symbol string, integer string, fraction string) {
+	m.ensureInitialized()
+
+	f := m.currency.Formatter()
+	rounded := m.amount.RoundBank(int32(f.Fraction))
+	if rounded.Sign() < 0 {
+		sign = "-"
+	}
+
+	integer, fraction = f.numberParts(rounded, false)
+
+	return sign, f.Grapheme, integer, fraction
+}
+
+// FormatPadded is FormattedStringBank, left padded with spaces to at least
+// width characters, so a column of amounts lines up on the right. Width is
+// counted in runes, so multi-byte graphemes like "€" count once.
+//
+// Example:
+//
+//     RequireFromString("USD", "5").FormatPadded(12)       // output: "       $5.00"
+//     RequireFromString("USD", "1234.56").FormatPadded(12) // output: "   $1,234.56"
+//
+func (m Money) FormatPadded(width int) string {
+	return padLeft(m.FormattedStringBank(), width)
+}
+
+// FormatAccountingPadded is FormattedStringAccounting, padded to width like
+// FormatPadded. Amounts which aren't in brackets get a trailing space, so
+// their digits line up with those of the negative amounts.
+//
+// Example:
+//
+//     RequireFromString("USD", "5").FormatAccountingPadded(10)  // output: "     5.00 "
+//     RequireFromString("USD", "-5").FormatAccountingPadded(10) // output: "    (5.00)"
+//
+func (m Money) FormatAccountingPadded(width int) string {
+	str := m.FormattedStringAccounting()
+	if !strings.HasSuffix(str, ")") {
+		str += " "
+	}
+
+	return padLeft(str, width)
+}
+
+// padLeft left pads str with spaces to width runes.
+func padLeft(str string, width int) string {
+	if n := utf8.RuneCountInString(str); n < width {
+		return strings.Repeat(" ", width-n) + str
+	}
+	return str
+}
+
+// StringWithCode formats m like FormattedStringBank, but with the ISO code in
+// front of the amount instead of the grapheme, as is usual on invoices. The
+// currency's separators are kept.
+//
+// Example:
+//
+//     RequireFromString("USD", "1234.56").StringWithCode()  // output: "USD 1,234.56"
+//     RequireFromString("USD", "-1234.56").StringWithCode() // output: "-USD 1,234.56"
+//
+func (m Money) StringWithCode() string {
+	m.ensureInitialized()
+
+	f := m.currency.Formatter()
+	f.Grapheme = m.currency.Code
+	f.Template = "$ 1"
+
+	return f.FormatCurrency(m.amount)
 }
 
-// GreaterThan (GT) returns true when d is greater than d2.
-func (m Money) GreaterThan(m2 Money) bool {
-	return m.Cmp(m2) == 1
+// FormatWithGrapheme is FormattedStringBank, but with g in place of the
+// currency's grapheme, ie. to tell US dollars from Canadian ones on the same
+// invoice. The currency itself isn't changed. An empty g keeps the currency's
+// own grapheme.
+//
+// Example:
+//
+//     RequireFromString("USD", "1234.56").FormatWithGrapheme("US$") // output: "US$1,234.56"
+//     RequireFromString("USD", "1234.56").FormatWithGrapheme("")    // output: "$1,234.56"
+//
+func (m Money) FormatWithGrapheme(g string) string {
+	m.ensureInitialized()
+
+	f := m.currency.Formatter()
+	if g != "" {
+		f.Grapheme = g
+	}
+
+	return f.FormatCurrency(m.amount)
 }
 
-// GreaterThanOrEqual (GTE) returns true when d is greater than or equal to d2.
-func (m Money) GreaterThanOrEqual(m2 Money) bool {
-	cmp := m.Cmp(m2)
-	return cmp == 1 || cmp == 0
+// FormatTrimmed is FormattedStringBank without the trailing zeros in the
+// fraction, which suits currencies with lots of places, like BTC. The amount
+// is still banker rounded to the currency's Fraction first, and the integer
+// part is still grouped.
+//
+// Example:
+//
+//     RequireFromString("BTC", "0.50000000").FormatTrimmed() // output: "₿0.5"
+//     RequireFromString("BTC", "1234.5").FormatTrimmed()     // output: "₿1,234.5"
+//     RequireFromString("USD", "100.00").FormatTrimmed()     // output: "$100"
+//
+func (m Money) FormatTrimmed() string {
+	m.ensureInitialized()
+
+	// String drops trailing zeros, so whatever is left after the point matters
+	rounded := m.amount.RoundBank(int32(m.currency.Fraction))
+
+	f := m.currency.Formatter()
+	f.Fraction = 0
+	if str := rounded.String(); strings.Contains(str, ".") {
+		f.Fraction = len(str) - strings.Index(str, ".") - 1
+	}
+
+	return f.FormatCurrency(rounded)
 }
 
-// LessThan (LT) returns true when d is less than d2.
-func (m Money) LessThan(m2 Money) bool {
-	return m.Cmp(m2) == -1
+// StringFixedBank returns a banker rounded fixed-point string with places digits
+// after the decimal point.
+//
+// Example:
+//
+// 	   NewFromFloat(0).StringFixed(2) // output: "0.00"
+// 	   NewFromFloat(0).StringFixed(0) // output: "0"
+// 	   NewFromFloat(5.45).StringFixed(0) // output: "5"
+// 	   NewFromFloat(5.45).StringFixed(1) // output: "5.4"
+// 	   NewFromFloat(5.45).StringFixed(2) // output: "5.45"
+// 	   NewFromFloat(5.45).StringFixed(3) // output: "5.450"
+// 	   NewFromFloat(545).StringFixed(-1) // output: "550"
+//
+func (m Money) FormattedStringAccounting() string {
+	m.ensureInitialized()
+
+	return m.currency.Formatter().FormatAccounting(m.amount)
 }
 
-// LessThanOrEqual (LTE) returns true when d is less than or equal to d2.
-func (m Money) LessThanOrEqual(m2 Money) bool {
-	cmp := m.Cmp(m2)
-	return cmp == -1 || cmp == 0
+// AccountingDash is the ZeroDisplay used by FormatAccountingDash.
+const AccountingDash = "\u2014"
+
+// FormatAccountingDash is FormattedStringAccounting, but with anything which
+// rounds to zero shown as an em dash, the way financial statements do.
+//
+// Example:
+//
+//     RequireFromString("USD", "-1234.5").FormatAccountingDash() // output: "(1234.50)"
+//     RequireFromString("USD", "0.001").FormatAccountingDash()   // output: "—"
+//
+func (m Money) FormatAccountingDash() string {
+	m.ensureInitialized()
+
+	f := m.currency.Formatter()
+	f.ZeroDisplay = AccountingDash
+
+	return f.FormatAccounting(m.amount)
 }
 
-// Sign returns:
+// FormatBankStatement formats m the way bank statements do, with no currency
+// grapheme, and DR or CR after the amount instead of a sign, as set in
+// BankStatementSignNames. Zero has no label.
 //
-//	-1 if d <  0
-//	 0 if d == 0
-//	+1 if d >  0
+// Example:
 //
-func (m Money) Sign() int {
+//     RequireFromString("USD", "-1234.56").FormatBankStatement() // output: "1,234.56 DR"
+//     RequireFromString("USD", "1234.56").FormatBankStatement()  // output: "1,234.56 CR"
+//     RequireFromString("USD", "0").FormatBankStatement()        // output: "0.00"
+//
+func (m Money) FormatBankStatement() string {
 	m.ensureInitialized()
-	return m.amount.Sign()
+
+	f := m.currency.Formatter()
+	f.TrailingDebitCredit = true
+	f.DebitCredit = BankStatementSignNames
+
+	return f.formatWithOptions(m.amount, RoundHalfEven, false, true, false)
 }
 
-// Exponent returns the exponent, or scale component of the decimal.
-func (m Money) Exponent() int32 {
+// FormattedStringFixedCash returns the amount Swedish/Cash rounded and
+// formatted for its currency, ie. "$3.45" for 3.43 at 5 cents. For more
+// details see the documentation at function RoundCash.
+func (m Money) FormattedStringFixedCash(interval uint8) string {
 	m.ensureInitialized()
-	return m.amount.Exponent()
+
+	return m.currency.Formatter().FormatCurrency(m.RoundCash(interval).amount)
 }
 
-// Coefficient returns the coefficient of the decimal.  It is scaled by 10^Exponent()
-func (m Money) Coefficient() *big.Int {
-	// we copy the coefficient so that mutating the result does not mutate the
-	// Decimal.
+// Round rounds the decimal to places decimal places.
+// If places < 0, it will round the integer part to the nearest 10^(-places).
+//
+// Example:
+//
+// 	   NewFromFloat(5.45).Round(1).String() // output: "5.5"
+// 	   NewFromFloat(545).Round(-1).String() // output: "550"
+//
+func (m Money) Round(places int32) Money {
 	m.ensureInitialized()
 
-	return m.amount.Coefficient()
+	return Money{
+		amount:   unsignedZero(m.amount.Round(places)),
+		currency: m.currency,
+	}
 }
 
-// IntPart returns the integer component of the decimal.
-func (m Money) IntPart() int64 {
+// RoundChecked is Round, also returning whether rounding changed the value, so
+// you can tell when precision was lost, ie. 1.50 to 1 place is 1.5 and not
+// lost, but 1.55 is 1.6 and lost.
+func (m Money) RoundChecked(places int32) (Money, bool) {
+	r := m.Round(places)
+	return r, !r.amount.Equal(m.amount)
+}
+
+// RoundBank rounds the decimal to places decimal places.
+// If the final digit to round is equidistant from the nearest two integers the
+// rounded value is taken as the even number
+//
+// If places < 0, it will round the integer part to the nearest 10^(-places).
+//
+// Examples:
+//
+// 	   NewFromFloat(5.45).Round(1).String() // output: "5.4"
+// 	   NewFromFloat(545).Round(-1).String() // output: "540"
+// 	   NewFromFloat(5.46).Round(1).String() // output: "5.5"
+// 	   NewFromFloat(546).Round(-1).String() // output: "550"
+// 	   NewFromFloat(5.55).Round(1).String() // output: "5.6"
+// 	   NewFromFloat(555).Round(-1).String() // output: "560"
+//
+func (m Money) RoundBank(places int32) Money {
 	m.ensureInitialized()
-	return m.amount.IntPart()
+
+	return Money{
+		amount:   unsignedZero(m.amount.RoundBank(places)),
+		currency: m.currency,
+	}
+
 }
 
-// Rat returns a rational number representation of the decimal.
-func (m Money) Rat() *big.Rat {
+// RoundCash aka Cash/Penny/öre rounding rounds decimal to a specific
+// interval. The amount payable for a cash transaction is rounded to the nearest
+// multiple of the minimum currency unit available. The following intervals are
+// available: 5, 10, 15, 25, 50 and 100; any other number throws a panic. The
+// result always has two decimal places.
+//	    5:   5 cent rounding 3.43 => 3.45
+// 	   10:  10 cent rounding 3.45 => 3.50 (5 gets rounded up)
+// 	   15:  10 cent rounding 3.45 => 3.40 (5 gets rounded down)
+// 	   25:  25 cent rounding 3.41 => 3.50
+// 	   50:  50 cent rounding 3.75 => 4.00
+// 	  100: 100 cent rounding 3.50 => 4.00
+// For more details: https://en.wikipedia.org/wiki/Cash_rounding
+func (m Money) RoundCash(interval uint8) Money {
 	m.ensureInitialized()
-	return m.amount.Rat()
+
+	if interval == 20 || !validCashInterval(interval) {
+		panic(fmt.Sprintf("Decimal does not support this Cash rounding interval `%d`. Supported: 5, 10, 15, 25, 50, 100", interval))
+	}
+
+	return Money{
+		amount:   unsignedZero(roundCashDecimal(m.amount, interval)),
+		currency: m.currency,
+	}
 }
 
-// Float64 returns the nearest float64 value for d and a bool indicating
-// whether f represents d exactly.
-// For more details, see the documentation for big.Rat.Float64
-func (m Money) Float64() (f float64, exact bool) {
+// RoundCashInterval is RoundCash that returns an error for an unsupported
+// interval rather than panicking, and that also knows 20 cent rounding. The
+// interval is in hundredths of a unit:
+//
+//	    5:   5 cent rounding 3.43 => 3.45
+// 	   10:  10 cent rounding 3.45 => 3.50 (5 gets rounded up)
+// 	   15:  10 cent rounding 3.45 => 3.40 (5 gets rounded down)
+// 	   20:  20 cent rounding 3.29 => 3.20, 3.30 => 3.40 (10 gets rounded up)
+// 	   25:  25 cent rounding 3.41 => 3.50
+// 	   50:  50 cent rounding 3.75 => 4.00
+// 	  100: 100 cent rounding 3.50 => 4.00
+func (m Money) RoundCashInterval(interval uint8) (Money, error) {
 	m.ensureInitialized()
-	return m.amount.Float64()
+
+	if !validCashInterval(interval) {
+		return m, newError(ErrInvalidArgument, nil, "Cash rounding interval [%d] not supported. Supported: 5, 10, 15, 20, 25, 50, 100", interval)
+	}
+
+	return Money{
+		amount:   unsignedZero(roundCashDecimal(m.amount, interval)),
+		currency: m.currency,
+	}, nil
 }
 
-// String returns a simple string representation of the decimal
-// with the fixed point.
-// Note: It does not pretty-print the amount with currency symbols or
-//   thousand separators. Use the CurrencyStringX functions if that's what you need.
+// RoundCashWithAdjustment is RoundCashInterval, also returning the rounding
+// adjustment, rounded - m, for the "rounding" line on a receipt. A positive
+// adjustment means the amount was rounded up.
+//
 // Example:
 //
-//     d := New("AUD",-12345, -3)
-//     println(d.String())
+//     rounded, adj, _ := RequireFromString("USD", "3.43").RoundCashWithAdjustment(5)
+//     // rounded: 3.45, adj: 0.02
 //
-// Output:
+// An error is returned, as by RoundCashInterval, for an unsupported interval.
+func (m Money) RoundCashWithAdjustment(interval uint8) (rounded Money, adjustment Money, err error) {
+	m.ensureInitialized()
+
+	rounded, err = m.RoundCashInterval(interval)
+	if err != nil {
+		return m, Money{amount: decimal.Zero, currency: m.currency}, err
+	}
+
+	adjustment = Money{
+		amount:   unsignedZero(rounded.amount.Sub(m.amount)),
+		currency: m.currency,
+	}
+
+	return rounded, adjustment, nil
+}
+
+// validCashInterval returns whether RoundCashInterval supports interval.
+func validCashInterval(interval uint8) bool {
+	switch interval {
+	case 5, 10, 15, 20, 25, 50, 100:
+		return true
+	}
+	return false
+}
+
+// RoundCashDefault rounds m to its currency's CashInterval, ie. to the nearest
+// 5 centimes for CHF. Currencies with no CashInterval are returned unchanged.
 //
-//     -12.345
+// NOTE: This will panic if the currency's CashInterval isn't one that
+// RoundCashInterval supports.
+func (m Money) RoundCashDefault() Money {
+	m.ensureInitialized()
+
+	if m.currency.CashInterval == 0 {
+		return m
+	}
+
+	r, err := m.RoundCashInterval(m.currency.CashInterval)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot cash round currency [%s]: %s", m.currency, err))
+	}
+
+	return r
+}
+
+// RoundLikeCurrency rounds m to the Fraction of the currency with the given
+// code, using DefaultRoundingMode, but keeps m's own currency. It's handy for
+// rough cross-currency estimates, ie. a USD amount in whole units like JPY.
 //
-//TODO Fix this.
-func (m Money) String() string {
+// Example:
+//
+//     RequireFromString("USD", "1234.567").RoundLikeCurrency("JPY") // 1235
+//     RequireFromString("USD", "1234.5678").RoundLikeCurrency("BHD") // 1234.568
+//
+// An error is returned if code isn't a known currency.
+func (m Money) RoundLikeCurrency(code string) (Money, error) {
 	m.ensureInitialized()
-	return m.amount.String()
+
+	c, ok := GetCurrency(code)
+	if !ok {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: code}
+	}
+
+	return Money{
+		amount:   unsignedZero(roundDecimal(m.amount, int32(c.Fraction), DefaultRoundingMode)),
+		currency: m.currency,
+	}, nil
 }
 
-// StringFixed returns a rounded fixed-point string with places digits after
-// the decimal point.
+// RoundToNearest rounds m to the nearest multiple of increment, ie. the nearest
+// 0.25 or the nearest 5, using DefaultRoundingMode when m is exactly halfway.
+// It's RoundCash for any increment you like.
 //
 // Example:
 //
-// 	   NewFromFloat(0).StringFixed(2) // output: "0.00"
-// 	   NewFromFloat(0).StringFixed(0) // output: "0"
-// 	   NewFromFloat(5.45).StringFixed(0) // output: "5"
-// 	   NewFromFloat(5.45).StringFixed(1) // output: "5.5"
-// 	   NewFromFloat(5.45).StringFixed(2) // output: "5.45"
-// 	   NewFromFloat(5.45).StringFixed(3) // output: "5.450"
-// 	   NewFromFloat(545).StringFixed(-1) // output: "550"
+//     RequireFromString("USD", "3.43").RoundToNearest(RequireFromString("USD", "0.25")) // 3.50
+//     RequireFromString("USD", "127").RoundToNearest(RequireFromString("USD", "5"))     // 125
 //
-//TODO Fix this.
-func (m Money) StringFixed(places int32) string {
+// An error is returned if increment isn't positive, or isn't in m's currency.
+func (m Money) RoundToNearest(increment Money) (Money, error) {
 	m.ensureInitialized()
+	increment.ensureInitialized()
 
-	return m.amount.StringFixed(places)
+	c, ok := m.currencyWith(increment)
+	if !ok {
+		return m, newError(ErrCurrencyMismatch, nil, "Cannot round to an increment of a mismatched currency m1[%s] m2[%s]", m.currency, increment.currency)
+	}
+
+	if increment.amount.Sign() <= 0 {
+		return m, newError(ErrInvalidArgument, nil, "Cannot round to an increment of [%s], it must be positive", increment.amount)
+	}
+
+	n := divRoundDecimal(m.amount, increment.amount, 0, DefaultRoundingMode)
+
+	return Money{
+		amount:   unsignedZero(n.Mul(increment.amount)),
+		currency: c,
+	}, nil
 }
 
-// StringFixedBank returns a banker rounded fixed-point string with places digits
-// after the decimal point.
+// SnapDirection is the way SnapToEndingWith moves an amount to reach the
+// ending.
+type SnapDirection int
+
+// Snap directions available.
+const (
+	SnapDown    SnapDirection = iota //	SnapDown	(the closest at or below. 12.40 => 11.99)
+	SnapUp                           //	SnapUp	(the closest at or above. 12.40 => 12.99)
+	SnapNearest                      //	SnapNearest	(whichever is closer, down when it's a tie. 12.40 => 11.99, 12.60 => 12.99)
+)
+
+// SnapToEnding moves m to a charm price, ie. one ending in .99 or .95, picking
+// the closest at or below m. ending is in minor units, so 99 for .99 in USD.
+// Use SnapToEndingWith to go up, or to the nearest.
 //
 // Example:
 //
-// 	   NewFromFloat(0).StringFixed(2) // output: "0.00"
-// 	   NewFromFloat(0).StringFixed(0) // output: "0"
-// 	   NewFromFloat(5.45).StringFixed(0) // output: "5"
-// 	   NewFromFloat(5.45).StringFixed(1) // output: "5.4"
-// 	   NewFromFloat(5.45).StringFixed(2) // output: "5.45"
-// 	   NewFromFloat(5.45).StringFixed(3) // output: "5.450"
-// 	   NewFromFloat(545).StringFixed(-1) // output: "550"
+//     RequireFromString("USD", "12.40").SnapToEnding(99) // 11.99
+//     RequireFromString("USD", "12.40").SnapToEnding(95) // 11.95
+//     RequireFromString("USD", "12.99").SnapToEnding(99) // 12.99
 //
-func (m Money) StringFixedBank(places int32) string {
+// An error is returned if the currency has no minor unit, or ending doesn't fit
+// in it, ie. isn't between 0 and 99 for USD.
+func (m Money) SnapToEnding(ending int) (Money, error) {
+	return m.SnapToEndingWith(ending, SnapDown)
+}
+
+// SnapToEndingWith is SnapToEnding, moving in the given direction.
+func (m Money) SnapToEndingWith(ending int, dir SnapDirection) (Money, error) {
 	m.ensureInitialized()
 
-	return m.amount.StringFixedBank(places)
+	places := int32(m.currency.Fraction)
+	if places == 0 || ending < 0 || decimal.New(int64(ending), -places).Cmp(decimal.New(1, 0)) >= 0 {
+		return m, newError(ErrInvalidArgument, nil, "Cannot snap currency [%s] to an ending of [%d]", m.currency, ending)
+	}
+
+	one := decimal.New(1, 0)
+	down := m.amount.Floor().Add(decimal.New(int64(ending), -places))
+	if down.Cmp(m.amount) > 0 {
+		down = down.Sub(one)
+	}
+
+	snapped := down
+	switch dir {
+	case SnapUp:
+		if down.Cmp(m.amount) < 0 {
+			snapped = down.Add(one)
+		}
+	case SnapNearest:
+		if up := down.Add(one); up.Sub(m.amount).Cmp(m.amount.Sub(down)) < 0 {
+			snapped = up
+		}
+	}
+
+	return Money{
+		amount:   unsignedZero(snapped),
+		currency: m.currency,
+	}, nil
+}
+
+// Floor returns the nearest integer value less than or equal to d.
+func (m Money) Floor() Money {
+	m.ensureInitialized()
+
+	return Money{
+		amount:   unsignedZero(m.amount.Floor()),
+		currency: m.currency,
+	}
 }
 
-func (m Money) StringFixedCash(interval uint8) string {
+// Ceil returns the nearest integer value greater than or equal to d.
+func (m Money) Ceil() Money {
 	m.ensureInitialized()
 
-	return m.amount.StringFixedCash(interval)
+	return Money{
+		amount:   unsignedZero(m.amount.Ceil()),
+		currency: m.currency,
+	}
 }
 
-// StringFixedBank returns a banker rounded fixed-point string with places digits
-// after the decimal point.
+// FloorTo returns m rounded down (towards negative infinity) to places decimal
+// places.
 //
 // Example:
 //
-// 	   NewFromFloat(0).StringFixed(2) // output: "0.00"
-// 	   NewFromFloat(0).StringFixed(0) // output: "0"
-// 	   NewFromFloat(5.45).StringFixed(0) // output: "5"
-// 	   NewFromFloat(5.45).StringFixed(1) // output: "5.4"
-// 	   NewFromFloat(5.45).StringFixed(2) // output: "5.45"
-// 	   NewFromFloat(5.45).StringFixed(3) // output: "5.450"
-// 	   NewFromFloat(545).StringFixed(-1) // output: "550"
+//     RequireFromString("USD", "1.2349").FloorTo(2).String()  // output: "1.23"
+//     RequireFromString("USD", "-1.2341").FloorTo(2).String() // output: "-1.24"
 //
-func (m Money) FormattedStringBank() string {
+func (m Money) FloorTo(places int32) Money {
 	m.ensureInitialized()
 
-	return m.currency.Formatter().FormatCurrency(m.amount)
+	return Money{
+		amount:   unsignedZero(roundDecimal(m.amount, places, RoundFloor)),
+		currency: m.currency,
+	}
 }
 
-// StringFixedBank returns a banker rounded fixed-point string with places digits
-// after the decimal point.
+// CeilTo returns m rounded up (towards positive infinity) to places decimal
+// places.
 //
 // Example:
 //
-// 	   NewFromFloat(0).StringFixed(2) // output: "0.00"
-// 	   NewFromFloat(0).StringFixed(0) // output: "0"
-// 	   NewFromFloat(5.45).StringFixed(0) // output: "5"
-// 	   NewFromFloat(5.45).StringFixed(1) // output: "5.4"
-// 	   NewFromFloat(5.45).StringFixed(2) // output: "5.45"
-// 	   NewFromFloat(5.45).StringFixed(3) // output: "5.450"
-// 	   NewFromFloat(545).StringFixed(-1) // output: "550"
+//     RequireFromString("USD", "1.2341").CeilTo(2).String()  // output: "1.24"
+//     RequireFromString("USD", "-1.2349").CeilTo(2).String() // output: "-1.23"
 //
-//TODO Fix this.
-func (m Money) FormattedStringAccounting() string {
+func (m Money) CeilTo(places int32) Money {
 	m.ensureInitialized()
 
-	return m.currency.Formatter().FormatAccounting(m.amount)
+	return Money{
+		amount:   unsignedZero(roundDecimal(m.amount, places, RoundCeil)),
+		currency: m.currency,
+	}
 }
 
-// StringFixedCash returns a Swedish/Cash rounded fixed-point string. For
-// more details see the documentation at function RoundCash.
-//TODO Fix this.
-func (m Money) FormattedStringFixedCash(interval uint8) string {
+// FloorFraction is FloorTo the currency's Fraction, ie. down to the cent for USD.
+func (m Money) FloorFraction() Money {
 	m.ensureInitialized()
 
-	return m.currency.Formatter().FormatCurrency(m.RoundCash(interval).amount)
+	return m.FloorTo(int32(m.currency.Fraction))
 }
 
-// Round rounds the decimal to places decimal places.
-// If places < 0, it will round the integer part to the nearest 10^(-places).
+// CeilFraction is CeilTo the currency's Fraction, ie. up to the cent for USD.
+func (m Money) CeilFraction() Money {
+	m.ensureInitialized()
+
+	return m.CeilTo(int32(m.currency.Fraction))
+}
+
+// Truncate truncates off digits from the number, without rounding.
+//
+// NOTE: precision is the last digit that will not be truncated (must be >= 0).
 //
 // Example:
 //
-// 	   NewFromFloat(5.45).Round(1).String() // output: "5.5"
-// 	   NewFromFloat(545).Round(-1).String() // output: "550"
+//     decimal.NewFromString("123.456").Truncate(2).String() // "123.45"
 //
-func (m Money) Round(places int32) Money {
+func (m Money) Truncate(precision int32) Money {
 	m.ensureInitialized()
 
 	return Money{
-		amount:   m.amount.Round(places),
+		amount:   unsignedZero(m.amount.Truncate(precision)),
 		currency: m.currency,
 	}
 }
 
-// RoundBank rounds the decimal to places decimal places.
-// If the final digit to round is equidistant from the nearest two integers the
-// rounded value is taken as the even number
-//
-// If places < 0, it will round the integer part to the nearest 10^(-places).
+// TruncateChecked is Truncate, also returning whether any non-zero digits were
+// dropped, ie. 1.230 to 2 places isn't lost, but 1.239 is.
+func (m Money) TruncateChecked(precision int32) (Money, bool) {
+	r := m.Truncate(precision)
+	return r, !r.amount.Equal(m.amount)
+}
+
+// TruncateToFraction is Truncate to the currency's Fraction, dropping anything
+// finer than the minor unit without rounding.
 //
-// Examples:
+// Example:
 //
-// 	   NewFromFloat(5.45).Round(1).String() // output: "5.4"
-// 	   NewFromFloat(545).Round(-1).String() // output: "540"
-// 	   NewFromFloat(5.46).Round(1).String() // output: "5.5"
-// 	   NewFromFloat(546).Round(-1).String() // output: "550"
-// 	   NewFromFloat(5.55).Round(1).String() // output: "5.6"
-// 	   NewFromFloat(555).Round(-1).String() // output: "560"
+//     RequireFromString("USD", "1.999").TruncateToFraction().String() // "1.99"
 //
-func (m Money) RoundBank(places int32) Money {
+func (m Money) TruncateToFraction() Money {
 	m.ensureInitialized()
 
-	return Money{
-		amount:   m.amount.RoundBank(places),
-		currency: m.currency,
-	}
+	return m.Truncate(int32(m.currency.Fraction))
+}
 
+// jsonMoney is the shape of a Money on the wire, ie. {"amount":"123.45","currency":"USD"}
+type jsonMoney struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
 }
 
-// RoundCash aka Cash/Penny/öre rounding rounds decimal to a specific
-// interval. The amount payable for a cash transaction is rounded to the nearest
-// multiple of the minimum currency unit available. The following intervals are
-// available: 5, 10, 15, 25, 50 and 100; any other number throws a panic.
-//	    5:   5 cent rounding 3.43 => 3.45
-// 	   10:  10 cent rounding 3.45 => 3.50 (5 gets rounded up)
-// 	   15:  10 cent rounding 3.45 => 3.40 (5 gets rounded down)
-// 	   25:  25 cent rounding 3.41 => 3.50
-// 	   50:  50 cent rounding 3.75 => 4.00
-// 	  100: 100 cent rounding 3.50 => 4.00
-// For more details: https://en.wikipedia.org/wiki/Cash_rounding
-func (m Money) RoundCash(interval uint8) Money {
-	m.ensureInitialized()
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The amount can be either a quoted string or a bare JSON number. A missing
+// currency decodes as UnknownCurrencyCode, same as Scan.
+func (m *Money) UnmarshalJSON(moneyBytes []byte) error {
+	if string(moneyBytes) == "null" {
+		return nil
+	}
 
-	return Money{
-		amount:   m.amount.RoundCash(interval),
-		currency: m.currency,
+	var jm jsonMoney
+	if err := json.Unmarshal(moneyBytes, &jm); err != nil {
+		return newError(ErrParse, err, "Error decoding string '%s': %s", moneyBytes, err)
 	}
-	// TODO: optimize those calculations to reduce the high allocations (~29 allocs).
-	// return d.Mul(dVal).Round(0).Div(dVal).Truncate(2)
-}
 
-// Floor returns the nearest integer value less than or equal to d.
-func (m Money) Floor() Money {
-	m.ensureInitialized()
+	str, err := unquoteIfQuoted([]byte(jm.Amount))
+	if err != nil {
+		return newError(ErrParse, err, "Error decoding string '%s': %s", moneyBytes, err)
+	}
 
-	return Money{
-		amount:   m.amount.Floor(),
-		currency: m.currency,
+	curr := jm.Currency
+	if curr == "" {
+		curr = UnknownCurrencyCode
+	}
+
+	mo, err := NewFromString(curr, str)
+	if err != nil {
+		return newError(ErrParse, err, "Error decoding string '%s': %s", moneyBytes, err)
 	}
+	*m = mo
+
+	return nil
 }
 
-// Ceil returns the nearest integer value greater than or equal to d.
-func (m Money) Ceil() Money {
+// MarshalJSON implements the json.Marshaler interface.
+// The amount is quoted unless MarshalJSONWithoutQuotes is set, and padded to
+// the currency's Fraction unless MarshalJSONFixedFraction is turned off.
+//
+// The output is canonical, so it can be compared byte for byte: the fields
+// are always amount then currency, there's no whitespace, and the amount is
+// written from the decimal, never via a float. Trailing zeros are trimmed
+// down to the Fraction (or all of them, without MarshalJSONFixedFraction), so
+// equal amounts marshal the same however they were constructed, ie. 1.5 and
+// 1.500 are both "1.50" for USD.
+func (m Money) MarshalJSON() ([]byte, error) {
 	m.ensureInitialized()
 
-	return Money{
-		amount:   m.amount.Ceil(),
-		currency: m.currency,
+	amount := m.String()
+	if MarshalJSONFixedFraction {
+		amount = m.canonicalAmount()
+	}
+	if !MarshalJSONWithoutQuotes {
+		amount = "\"" + amount + "\""
+	}
+
+	curr, err := json.Marshal(m.currency.Code)
+	if err != nil {
+		return nil, err
 	}
+
+	return []byte(`{"amount":` + amount + `,"currency":` + string(curr) + `}`), nil
 }
 
-// Truncate truncates off digits from the number, without rounding.
+// Binary format versions. MarshalBinary always writes the latest one, and
+// UnmarshalBinary reads any of them, so blobs and gob streams written by
+// older releases keep working.
 //
-// NOTE: precision is the last digit that will not be truncated (must be >= 0).
+// Version 0 is the original layout. It was written without a version byte,
+// and is recognised by its first byte being a printable character rather than
+// a version number, but can also be tagged with an explicit 0x00:
 //
-// Example:
+//     [3 bytes currency code][4 bytes exponent][coefficient]
+//     [0x00][3 bytes currency code][4 bytes exponent][coefficient]
 //
-//     decimal.NewFromString("123.456").Truncate(2).String() // "123.45"
+// Version 1 adds the version byte, and a length byte ahead of the currency
+// code, so codes of any length (up to 255 bytes) can be stored:
 //
-func (m Money) Truncate(precision int32) Money {
-	m.ensureInitialized()
+//     [0x01][1 byte length][currency code][4 bytes exponent][coefficient]
+//
+// The exponent is a big endian int32, and the coefficient is a big.Int as
+// written by its GobEncode.
+const (
+	binaryVersionLegacy = 0
+	binaryVersion1      = 1
+	binaryVersion       = binaryVersion1
 
-	return Money{
-		amount:   m.amount.Truncate(precision),
-		currency: m.currency,
-	}
-}
+	// First bytes below this are version numbers, the rest start a legacy
+	// currency code
+	binaryVersionLimit = 0x20
+)
 
-// TODO
-// UnmarshalJSON implements the json.Unmarshaler interface.
-//func (d *Decimal) UnmarshalJSON(decimalBytes []byte) error {
-//	if string(decimalBytes) == "null" {
-//		return nil
-//	}
-//
-//	str, err := unquoteIfQuoted(decimalBytes)
-//	if err != nil {
-//		return fmt.Errorf("Error decoding string '%s': %s", decimalBytes, err)
-//	}
-//
-//	decimal, err := NewFromString(str)
-//	*d = decimal
-//	if err != nil {
-//		return fmt.Errorf("Error decoding string '%s': %s", str, err)
-//	}
-//	return nil
-//}
-
-// TODO
-// MarshalJSON implements the json.Marshaler interface.
-//func (d Decimal) MarshalJSON() ([]byte, error) {
-//	var str string
-//	if MarshalJSONWithoutQuotes {
-//		str = d.String()
-//	} else {
-//		str = "\"" + d.String() + "\""
-//	}
-//	return []byte(str), nil
-//}
-
-// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. As a string representation
-// is already used when encoding to text, this method stores that string as []byte
-// NOTE: This is going to break really badlyif we have non ASCII
-//    chars in the currency code. Should probably add a length byte at the start
-//    but cannot be arsed right now.
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// reads every version of the format MarshalBinary has ever written.
 func (m *Money) UnmarshalBinary(data []byte) error {
 
-	var err error
-	var mo Money
+	if len(data) == 0 {
+		return newError(ErrParse, nil, "Not enough data - only found [0] bytes")
+	}
 
-	if ld := len(data); ld < 8 {
-		err = fmt.Errorf("Not enough data - only found [%v] bytes", ld)
-	} else {
-		// Extract the exponent
-		curr := string(data[:3])
+	// Everything after the version byte, or the lot if there isn't one
+	version, body := int(data[0]), data[1:]
+	if version >= binaryVersionLimit {
+		version, body = binaryVersionLegacy, data
+	}
 
-		// Extract the exponent
-		exp := int32(binary.BigEndian.Uint32(data[3:7]))
+	var curr string
 
-		// Extract the value
-		v := new(big.Int)
+	switch version {
+	case binaryVersionLegacy:
+		if len(body) < 8 {
+			return newError(ErrParse, nil, "Not enough data - only found [%v] bytes", len(data))
+		}
+		curr, data = string(body[:3]), body[3:]
 
-		if err = v.GobDecode(data[7:]); err == nil {
-			mo, _ = NewFromBigInt(curr, v, exp)
-			*m = mo
-		} else {
+	case binaryVersion1:
+		if len(body) < 1 || len(body) < 1+int(body[0])+5 {
+			return newError(ErrParse, nil, "Not enough data - only found [%v] bytes", len(data))
 		}
+		n := int(body[0])
+		curr, data = string(body[1:1+n]), body[1+n:]
+
+	default:
+		return newError(ErrParse, nil, "Binary format version [%d] not supported", version)
+	}
+
+	// Extract the exponent
+	exp := int32(binary.BigEndian.Uint32(data[:4]))
+
+	// Extract the value
+	v := new(big.Int)
+	if err := v.GobDecode(data[4:]); err != nil {
+		return newError(ErrParse, err, "Error decoding binary: %s", err)
+	}
+
+	mo, err := NewFromBigInt(curr, v, exp)
+	if err != nil {
+		return err
 	}
+	*m = mo
 
-	return err
+	return nil
 }
 
-// MarshalBinary implements the encoding.BinaryMarshaler interface.
-// NOTE: This is going to break really badlyif we have non ASCII
-//    chars in the currency code. Should probably add a length byte at the start
-//    but cannot be arsed right now.
+// MarshalBinary implements the encoding.BinaryMarshaler interface. It always
+// writes the latest of the binary format versions described above.
 func (m Money) MarshalBinary() (data []byte, err error) {
-	// Write currency first as it's meant to be a fixed size (3 bytes)
-	b1 := []byte(m.currency.Code)
+	m.ensureInitialized()
+
+	// Version, then the currency with its length
+	code := []byte(m.currency.Code)
+	if len(code) > math.MaxUint8 {
+		return nil, newError(ErrInvalidArgument, nil, "Cannot marshal currency [%s] to binary, code must be at most %d bytes", m.currency.Code, math.MaxUint8)
+	}
+
+	data = make([]byte, 0, 2+len(code)+4)
+	data = append(data, binaryVersion, byte(len(code)))
+	data = append(data, code...)
 
 	// Write the exponent next since it's a fixed size
 	b2 := make([]byte, 4)
 	binary.BigEndian.PutUint32(b2, uint32(m.Exponent()))
 
-	b1 = append(b1, b2...)
+	data = append(data, b2...)
 
 	// Add the value
 	var b3 []byte
 	var mCo = m.Coefficient()
 	if b3, err = mCo.GobEncode(); err != nil {
-		return
+		return nil, err
 	}
 
 	// Return the byte array
-	data = append(b1, b3...)
+	data = append(data, b3...)
 
-	return
+	return data, nil
 }
 
 // Scan implements the sql.Scanner interface for database deserialization.
+// Strings can be a plain amount, the "USD 123.45" form written by Value in
+// ValueComposite mode, or formatted like a Postgres money column, ie.
+// "$1,234.56" or "($1,234.56)".
 func (m *Money) Scan(value interface{}) error {
 	// first try to see if the data is stored in database as a Numeric datatype
 	switch v := value.(type) {
@@ -786,39 +2774,161 @@ func (m *Money) Scan(value interface{}) error {
 		return nil
 
 	default:
-		// default is trying to interpret value stored as string
+		// default is trying to interpret value stored as string, ie. a
+		// numeric from lib/pq or pgx. This is parsed straight into the
+		// decimal, never via a float, so no precision is lost. The
+		// "USD 123.45" form written by MarshalText is accepted too.
 		str, err := unquoteIfQuoted(v)
 		if err != nil {
 			return err
 		}
-		*m, err = NewFromString(UnknownCurrencyCode, str)
+		err = m.UnmarshalText([]byte(str))
+		if err == nil {
+			return nil
+		}
+
+		// Failing that, it may be formatted, ie. from a Postgres money
+		// column. If it isn't, the first error says more.
+		if m.scanFormatted(str) {
+			return nil
+		}
 		return err
 	}
 }
 
+// scanFormatted reads a formatted amount, such as Postgres gives for a money
+// column, ie. "$1,234.56", "-$1,234.56" or "($1,234.56)". It's parsed with
+// the default currency's Formatter (see SetDefaultCurrency), and the result
+// is in that currency. Without a default that's UnknownCurrencyCode, which
+// formats like USD, as Postgres does in the usual en_US locale. Any thousands
+// separators have to be where the Formatter would put them, so a mangled value
+// (ie. "12,34") isn't read as a different amount. m is only set if it parses.
+func (m *Money) scanFormatted(str string) bool {
+	c, ok := GetCurrency(DefaultCurrency())
+	if !ok {
+		c = getUnknownCurrency()
+	}
+
+	d, err := c.Formatter().parse(str, true)
+	if err != nil {
+		return false
+	}
+
+	*m = Money{amount: d, currency: c}
+	return true
+}
+
 // Value implements the driver.Valuer interface for database serialization.
 func (m Money) Value() (driver.Value, error) {
+	if ValueMode == ValueComposite {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
 	return m.String(), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface for XML
-// deserialization.
+// deserialization. It reads the "USD 123.45" form written by MarshalText. A
+// bare amount, as written by older versions, decodes as UnknownCurrencyCode.
 func (d *Money) UnmarshalText(text []byte) error {
-	str := string(text)
+	str := strings.TrimSpace(string(text))
 
-	dec, err := NewFromString(UnknownCurrencyCode, str)
+	curr, amount := UnknownCurrencyCode, str
+	if i := strings.Index(str, " "); i >= 0 {
+		curr, amount = str[:i], strings.TrimSpace(str[i+1:])
+	}
+
+	dec, err := NewFromString(curr, amount)
 	*d = dec
+	if errors.Is(err, ErrUnsupportedCurrency) {
+		return err
+	}
 	if err != nil {
-		return fmt.Errorf("Error decoding string '%s': %s", str, err)
+		return newError(ErrParse, err, "Error decoding string '%s': %s", str, err)
 	}
 
 	return nil
 }
 
 // MarshalText implements the encoding.TextMarshaler interface for XML
-// serialization.
+// serialization. The currency code comes first, ie. "USD 123.45", unless it's
+// UnknownCurrencyCode, in which case it's just the amount.
+//
+// encoding/json uses this for map keys, so a map[Money]int marshals as
+// {"USD 123.45":1}. Bear in mind Moneys are compared by pointer as map keys,
+// so 1.5 and 1.50 (or two separately parsed 1.5s) are different keys; Key is
+// usually a better choice for a map that you look things up in.
 func (d Money) MarshalText() (text []byte, err error) {
-	return []byte(d.String()), nil
+	d.ensureInitialized()
+
+	if d.currency.Code == UnknownCurrencyCode {
+		return []byte(d.String()), nil
+	}
+
+	return []byte(d.currency.Code + " " + d.String()), nil
+}
+
+// CSVCell returns m as a single CSV cell, ie. "USD 123.45", the same as
+// MarshalText. It's one cell rather than two so that it needs no quoting and
+// the amount can't get separated from its currency when columns are moved
+// about. The amount is written in full, with no rounding or thousands
+// separators, so nothing is lost; use ParseCSVCell to read it back.
+//
+// NOTE: Spreadsheets will treat the cell as text, not a number. Export
+// String or AmountString in a column of its own if you need to do sums.
+func (m Money) CSVCell() string {
+	text, _ := m.MarshalText()
+	return string(text)
+}
+
+// ParseCSVCell reads a cell written by CSVCell. A bare amount is taken to be in
+// UnknownCurrencyCode.
+func ParseCSVCell(s string) (Money, error) {
+	var m Money
+	err := m.UnmarshalText([]byte(s))
+	return m, err
+}
+
+// MarshalXML implements the xml.Marshaler interface. The currency goes in an
+// attribute, ie. <price currency="USD">123.45</price>. A Money in
+// UnknownCurrencyCode has no attribute, just the amount.
+func (m Money) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	m.ensureInitialized()
+
+	if m.currency.Code != UnknownCurrencyCode {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "currency"}, Value: m.currency.Code})
+	}
+
+	return e.EncodeElement(m.String(), start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface, reading the element
+// written by MarshalXML. Without a currency attribute, the content is read the
+// same as UnmarshalText, so "USD 123.45" and a bare amount still work.
+func (m *Money) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	var content string
+	if err := d.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+
+	for _, attr := range start.Attr {
+		if attr.Name.Local != "currency" {
+			continue
+		}
+
+		mo, err := NewFromString(attr.Value, strings.TrimSpace(content))
+		*m = mo
+		if errors.Is(err, ErrUnsupportedCurrency) {
+			return err
+		}
+		if err != nil {
+			return newError(ErrParse, err, "Error decoding string '%s': %s", content, err)
+		}
+		return nil
+	}
+
+	return m.UnmarshalText([]byte(content))
 }
 
 // GobEncode implements the gob.GobEncoder interface for gob serialization.
@@ -839,6 +2949,32 @@ func (m *Money) ensureInitialized() {
 	}
 }
 
+// currencyWith returns the currency of the result of an operation between m
+// and m2, or false if they can't be mixed. See AllowUnknownCurrencyOps.
+func (m Money) currencyWith(m2 Money) (*Currency, bool) {
+	switch {
+	case m.currency.equals(m2.currency):
+		return m.currency, true
+	case !AllowUnknownCurrencyOps:
+		return nil, false
+	case m.currency.Code == UnknownCurrencyCode:
+		return m2.currency, true
+	case m2.currency.Code == UnknownCurrencyCode:
+		return m.currency, true
+	}
+	return nil, false
+}
+
+// unsignedZero makes sure a zero amount really is a plain zero, whatever sign
+// the operation that produced it would have given it. Negative zero money
+// doesn't mean anything, so it shouldn't survive a Neg or a round.
+func unsignedZero(d decimal.Decimal) decimal.Decimal {
+	if d.Sign() != 0 {
+		return d
+	}
+	return decimal.New(0, d.Exponent())
+}
+
 // Min returns the smallest Decimal that was passed in the arguments.
 //
 // To call this function with an array, you must do:
@@ -873,6 +3009,118 @@ func Max(first Money, rest ...Money) Money {
 	return ans
 }
 
+// MinValid returns the smallest of the Valid Moneys passed in, skipping the
+// rest, ie. zero value Moneys from optional columns. Unlike Min it can be
+// called with a slice directly:
+//
+//     MinValid(arr...)
+//
+// An error is returned if none of them are Valid, or the Valid ones don't all
+// have the same currency.
+func MinValid(ms ...Money) (Money, error) {
+	return pickValid(ms, -1)
+}
+
+// MaxValid returns the largest of the Valid Moneys passed in, skipping the
+// rest. See MinValid.
+func MaxValid(ms ...Money) (Money, error) {
+	return pickValid(ms, 1)
+}
+
+// pickValid returns the Valid Money which compares as want (-1 or 1) against
+// all the other Valid ones.
+func pickValid(ms []Money, want int) (Money, error) {
+
+	var ans Money
+	found := false
+
+	for _, m := range ms {
+		if !m.Valid() {
+			continue
+		}
+		if !found {
+			ans, found = m, true
+			continue
+		}
+		if !m.currency.equals(ans.currency) {
+			return Money{amount: decimal.Zero, currency: getBadCurrency()},
+				newError(ErrCurrencyMismatch, nil, "Cannot compare amounts with mismatched currencies m1[%s] m2[%s]", ans.currency, m.currency)
+		}
+		if m.amount.Cmp(ans.amount) == want {
+			ans = m
+		}
+	}
+
+	if !found {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, newError(ErrInvalidArgument, nil, "Cannot pick from [%d] Moneys, none of them are valid", len(ms))
+	}
+
+	return ans, nil
+}
+
+// EqualAll returns whether every Money passed in is numerically equal, ie.
+// 1.5 and 1.50 are. With fewer than two Moneys there's nothing to differ, so
+// it returns true.
+//
+// NOTE: Like Equal, this will panic if the currencies don't all match.
+func EqualAll(ms ...Money) bool {
+	for i := 1; i < len(ms); i++ {
+		if !ms[0].Equal(ms[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// FirstMismatch returns the index of the first Money whose currency differs
+// from that of ms[0], and true, or false if they all match. Unlike the
+// arithmetic, it never panics, so it suits checking input up front.
+//
+// Example:
+//
+//     i, found := FirstMismatch(items) // 2, true for USD 1, USD 2, EUR 3
+//
+// NOTE: Currencies are compared strictly, so UnknownCurrencyCode is a mismatch
+// with anything else, whatever AllowUnknownCurrencyOps says.
+func FirstMismatch(ms []Money) (int, bool) {
+	if len(ms) == 0 {
+		return 0, false
+	}
+
+	first := ms[0]
+	first.ensureInitialized()
+
+	for i := 1; i < len(ms); i++ {
+		m := ms[i]
+		m.ensureInitialized()
+
+		if !m.currency.equals(first.currency) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// AllSameCurrency returns true when every Money in ms has the same currency,
+// as checked by FirstMismatch. An empty slice has nothing to differ, so it
+// returns true.
+func AllSameCurrency(ms []Money) bool {
+	_, found := FirstMismatch(ms)
+	return !found
+}
+
+// AllPositive returns true when every Money in ms is greater than zero, in
+// whatever currency. An empty slice returns true.
+func AllPositive(ms []Money) bool {
+	for _, m := range ms {
+		if !m.GreaterThanZero() {
+			return false
+		}
+	}
+	return true
+}
+
 // Sum returns the combined total of the provided first and rest Decimals
 func Sum(first Money, rest ...Money) Money {
 	total := first
@@ -883,11 +3131,28 @@ func Sum(first Money, rest ...Money) Money {
 	return total
 }
 
-// Avg returns the average value of the provided first and rest Decimals
+// Avg returns the average value of the provided first and rest Moneys, rounded
+// to the currency's Fraction using DefaultRoundingMode. Averaging a single
+// Money returns it unchanged.
+//
+// NOTE: Averaging identical amounts that already sit on the currency's fraction
+// gives back exactly that amount, so repeated averaging doesn't drift. Amounts
+// carrying more precision than the currency allows get rounded like any other
+// result.
 func Avg(first Money, rest ...Money) Money {
-	count, _ := New(first.currency.Code, int64(len(rest)+1), 0)
+	if len(rest) == 0 {
+		return first
+	}
+
+	first.ensureInitialized()
+
+	count := decimal.New(int64(len(rest)+1), 0)
 	sum := Sum(first, rest...)
-	return sum.Div(count)
+
+	return Money{
+		amount:   divRoundDecimal(sum.amount, count, int32(sum.currency.Fraction), DefaultRoundingMode),
+		currency: sum.currency,
+	}
 }
 
 func min(x, y int32) int32 {
@@ -906,7 +3171,7 @@ func unquoteIfQuoted(value interface{}) (string, error) {
 	case []byte:
 		bytes = v
 	default:
-		return "", fmt.Errorf("Could not convert value '%+v' to byte array of type '%T'",
+		return "", newError(ErrParse, nil, "Could not convert value '%+v' to byte array of type '%T'",
 			value, value)
 	}
 
@@ -942,20 +3207,20 @@ func (d NullMoney) Value() (driver.Value, error) {
 	return d.Money.Value()
 }
 
-//// UnmarshalJSON implements the json.Unmarshaler interface.
-//func (d *NullMoney) UnmarshalJSON(decimalBytes []byte) error {
-//	if string(decimalBytes) == "null" {
-//		d.Valid = false
-//		return nil
-//	}
-//	d.Valid = true
-//	return d.Money.UnmarshalJSON(decimalBytes)
-//}
-//
-//// MarshalJSON implements the json.Marshaler interface.
-//func (d NullMoney) MarshalJSON() ([]byte, error) {
-//	if !d.Valid {
-//		return []byte("null"), nil
-//	}
-//	return d.Money.MarshalJSON()
-//}
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *NullMoney) UnmarshalJSON(moneyBytes []byte) error {
+	if string(moneyBytes) == "null" {
+		d.Valid = false
+		return nil
+	}
+	d.Valid = true
+	return d.Money.UnmarshalJSON(moneyBytes)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d NullMoney) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return d.Money.MarshalJSON()
+}
@@ -1,9 +1,14 @@
 package money
 
 import (
+	"bytes"
 	"database/sql/driver"
-	//	"encoding/json"
+	"encoding/csv"
+	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
 	"math"
 	"math/big"
 	"reflect"
@@ -12,6 +17,7 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 )
 
 type testEnt struct {
@@ -170,6 +176,128 @@ func TestFloat64(t *testing.T) {
 	}
 }
 
+func TestMoney_FitsCurrencyScale(t *testing.T) {
+	padded, _ := New("USD", 1990, -3)
+
+	tests := []struct {
+		m        Money
+		expected bool
+	}{
+		{RequireFromString("USD", "1.99"), true},
+		{RequireFromString("USD", "1.999"), false},
+		{RequireFromString("USD", "-0.001"), false},
+		{RequireFromString("USD", "100"), true},
+		{padded, false},
+		{RequireFromString("JPY", "1.5"), false},
+		{RequireFromString("BTC", "0.00000001"), true},
+		{RequireFromString("BTC", "0.000000001"), false},
+		{Money{}, true},
+	}
+
+	for _, test := range tests {
+		if got := test.m.FitsCurrencyScale(); got != test.expected {
+			t.Errorf("%s %s: expected %t got %t", test.m.currency, test.m, test.expected, got)
+		}
+
+		got, err := test.m.EnsureCurrencyScale()
+		if test.expected != (err == nil) {
+			t.Errorf("%s %s: expected fit %t, got error %v", test.m.currency, test.m, test.expected, err)
+		}
+		if !got.Equals(test.m) {
+			t.Errorf("%s %s: expected it back unchanged, got %s", test.m.currency, test.m, got)
+		}
+
+		if p := didPanic(func() { test.m.MustFitCurrencyScale() }); p == test.expected {
+			t.Errorf("%s %s: expected a panic %t", test.m.currency, test.m, !test.expected)
+		}
+	}
+}
+
+func TestMoney_DebugString(t *testing.T) {
+	usd, _ := New("USD", 12345, -2)
+	trailing, _ := New("USD", 1500, -3)
+	jpy, _ := New("JPY", -7, 2)
+
+	tests := []struct {
+		m        Money
+		expected string
+	}{
+		{usd, "Money{coefficient:12345, exp:-2, currency:USD} = 123.45"},
+		{trailing, "Money{coefficient:1500, exp:-3, currency:USD} = 1.5"},
+		{jpy, "Money{coefficient:-7, exp:2, currency:JPY} = -700"},
+		{Money{}, "Money{coefficient:0, exp:0, currency:" + UnknownCurrencyCode + "} = 0"},
+	}
+
+	for _, test := range tests {
+		if got := test.m.DebugString(); got != test.expected {
+			t.Errorf("expected %s, got %s", test.expected, got)
+		}
+	}
+}
+
+func TestMoney_RatExact(t *testing.T) {
+	third := RequireFromString("USD", "1").Div(RequireFromString("USD", "3"))
+	quarter := RequireFromString("USD", "1").Div(RequireFromString("USD", "4"))
+
+	tests := []struct {
+		m        Money
+		rat      string
+		expected bool
+	}{
+		{RequireFromString("USD", "0.25"), "1/4", true},
+		{RequireFromString("USD", "-12.50"), "-25/2", true},
+		{RequireFromString("USD", "3"), "3", true},
+		{RequireFromString("USD", "0"), "0", true},
+		{quarter, "1/4", true},
+		{RequireFromString("USD", "0.125"), "1/8", true},
+		{RequireFromString("JPY", "0.25"), "1/4", true},
+		{third, "33333333333333333333/100000000000000000000", false},
+		{third.Neg(), "-33333333333333333333/100000000000000000000", false},
+		// Parsed, but it has all DivisionPrecision places, so looks the same
+		{RequireFromString("USD", "0.00000000000000000001"), "1/100000000000000000000", false},
+	}
+
+	for _, test := range tests {
+		if s := test.m.RatString(); s != test.rat {
+			t.Errorf("%s %s: expected RatString %s got %s", test.m.currency, test.m, test.rat, s)
+		}
+
+		r, exact := test.m.RatExact()
+		if r.RatString() != test.rat || exact != test.expected {
+			t.Errorf("%s %s: expected %s %t got %s %t", test.m.currency, test.m, test.rat, test.expected, r.RatString(), exact)
+		}
+	}
+
+	// 1/3 has already been rounded by the division, so it isn't a third
+	if r, _ := third.RatExact(); r.Cmp(big.NewRat(1, 3)) == 0 {
+		t.Errorf("expected a rounded third, got %s", r.RatString())
+	}
+}
+
+func TestFloat32(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected float32
+		exact    bool
+	}{
+		{"2.5", 2.5, true},
+		{"-1024.125", -1024.125, true},
+		{"16777216", 16777216, true},
+		{"0", 0, true},
+		{"0.1", 0.1, false},
+		{"123456.78", 123456.78, false},
+		{"16777217", 16777216, false},
+		{"-0.000000001", -0.000000001, false},
+	}
+
+	for _, test := range tests {
+		f, exact := RequireFromString("USD", test.value).Float32()
+		if f != test.expected || exact != test.exact {
+			t.Errorf("%s: expected %v (exact %v), got %v (exact %v)", test.value, test.expected, test.exact, f, exact)
+		}
+	}
+}
+
 func TestNewFromStringErrs(t *testing.T) {
 	tests := []string{
 		"",
@@ -213,6 +341,110 @@ func TestNewFromStringErrs(t *testing.T) {
 	}
 }
 
+func TestNewFromLocaleString(t *testing.T) {
+	tests := []struct {
+		curr     string
+		value    string
+		decPoint string
+		thousand string
+		expected string
+	}{
+		{"USD", "1.234,56", ",", ".", "1234.56"},
+		{"EUR", "-1.234.567,8", ",", ".", "-1234567.8"},
+		{"EUR", "(1.234,56)", ",", ".", "-1234.56"},
+		{"EUR", "1\u00a0234,56", ",", "\u00a0", "1234.56"},
+		{"CHF", "1'234.56", ".", "'", "1234.56"},
+		{"USD", "1,234.56", ".", ",", "1234.56"},
+		{"USD", "1234", ",", ".", "1234"},
+		{"USD", "0,5", ",", "", "0.5"},
+		{"USD", "12.345.678", ",", ".", "12345678"},
+		{"USD", ",5", ",", ".", "0.5"},
+	}
+
+	for _, test := range tests {
+		got, err := NewFromLocaleString(test.curr, test.value, test.decPoint, test.thousand)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error %s", test.curr, test.value, err)
+		} else if got.currency.Code != test.curr || got.String() != test.expected {
+			t.Errorf("%s %q: expected %s got %s %s", test.curr, test.value, test.expected, got.currency, got)
+		}
+	}
+}
+
+func TestNewFromLocaleStringErrs(t *testing.T) {
+	tests := []struct {
+		value    string
+		decPoint string
+		thousand string
+	}{
+		{"1.234,56", ",", ","},
+		{"1.234,56", "", "."},
+		{"1,234.56", ",", "."},
+		{"1,234,56", ",", "."},
+		{"1.234,56 EUR", ",", "."},
+		{"", ",", "."},
+		// Grouped wrongly, so maybe in another locale
+		{".5", ",", "."},
+		{"0.5", ",", "."},
+		{"1.2.3,5", ",", "."},
+		{"12.34,5", ",", "."},
+		{"1..000", ",", "."},
+		{"1.234.", ",", "."},
+		{"1.2345,6", ",", "."},
+		{"1,", ",", "."},
+	}
+
+	for _, test := range tests {
+		if got, err := NewFromLocaleString("EUR", test.value, test.decPoint, test.thousand); !errors.Is(err, ErrParse) {
+			t.Errorf("%q (%q, %q): expected ErrParse, got %s %v", test.value, test.decPoint, test.thousand, got, err)
+		}
+	}
+
+	if _, err := NewFromLocaleString("XXXX", "1,5", ",", "."); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestNewFromString_Scientific(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{"1.2345E+4", "12345"},
+		{"1.2345e4", "12345"},
+		{"1e-3", "0.001"},
+		{"-2.5E2", "-250"},
+		{"12345E-2", "123.45"},
+		{"0E+10", "0"},
+	}
+
+	for _, test := range tests {
+		m, err := NewFromString("USD", test.s)
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", test.s, err)
+		} else if m.String() != test.expected {
+			t.Errorf("%s: expected %s got %s", test.s, test.expected, m)
+		}
+
+		// And the same through Scan
+		var scanned Money
+		if err := scanned.Scan("USD " + test.s); err != nil || scanned.String() != test.expected || scanned.currency.Code != "USD" {
+			t.Errorf("Scan(USD %s): expected USD %s got %s %s (%v)", test.s, test.expected, scanned.currency, scanned, err)
+		}
+	}
+
+	for _, s := range []string{"1e", "1e+", "e5", "1e2.5", "1E--3", "1e3e3"} {
+		if _, err := NewFromString("USD", s); !errors.Is(err, ErrParse) {
+			t.Errorf("%s: expected ErrParse, got %v", s, err)
+		}
+
+		var scanned Money
+		if err := scanned.Scan(s); !errors.Is(err, ErrParse) {
+			t.Errorf("Scan(%s): expected ErrParse, got %v", s, err)
+		}
+	}
+}
+
 func TestNewFromStringDeepEquals(t *testing.T) {
 	type StrCmp struct {
 		str1     string
@@ -276,6 +508,127 @@ func TestRequireFromStringErrs(t *testing.T) {
 	}
 }
 
+func TestNewFromStringStrict(t *testing.T) {
+	tests := []struct {
+		curr     string
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"USD", "1.99", "1.99", true},
+		{"USD", "1.9900", "1.99", true},
+		{"USD", "1.990", "1.99", true},
+		{"USD", "1.9901", "", false},
+		{"USD", "-1234", "-1234", true},
+		{"USD", "1.005", "", false},
+		{"USD", "0.001", "", false},
+		{"JPY", "100", "100", true},
+		{"JPY", "100.5", "", false},
+		{"JPY", "100.000", "100", true},
+		{"BTC", "0.00000001", "0.00000001", true},
+		{"BTC", "0.000000001", "", false},
+		{"USD", "abc", "", false},
+		{"I*am*Not*a*Currency", "1", "", false},
+	}
+
+	for _, test := range tests {
+		m, err := NewFromStringStrict(test.curr, test.value)
+		if !test.ok {
+			if err == nil {
+				t.Errorf("%s %s: expected error, got %s", test.curr, test.value, m)
+			}
+		} else if err != nil {
+			t.Errorf("%s %s: unexpected error %s", test.curr, test.value, err)
+		} else if m.String() != test.expected {
+			t.Errorf("%s %s: expected %s got %s", test.curr, test.value, test.expected, m)
+		}
+	}
+}
+
+func TestNewFromFloatRounded(t *testing.T) {
+	tests := []struct {
+		curr     string
+		float    float64
+		expected string
+	}{
+		{"USD", 123.456, "123.46"},
+		{"USD", 123.45678901234567, "123.46"},
+		{"USD", -123.456, "-123.46"},
+		{"USD", 2.675, "2.68"},
+		{"USD", 2.665, "2.66"},
+		{"USD", 0.001, "0"},
+		{"JPY", 123.5, "124"},
+		{"BHD", 1.23456, "1.235"},
+	}
+
+	for _, test := range tests {
+		m, err := NewFromFloatRounded(test.curr, test.float)
+		if err != nil {
+			t.Errorf("%s %v: unexpected error %s", test.curr, test.float, err)
+		} else if m.String() != test.expected {
+			t.Errorf("%s %v: expected %s got %s", test.curr, test.float, test.expected, m)
+		}
+	}
+
+	// Lands exactly on the currency's Fraction, float noise and all
+	noisy := 0.1
+	noisy += 0.2
+	for _, test := range []struct {
+		curr     string
+		float    float64
+		expected string
+	}{
+		{"USD", noisy, "0.30"},
+		{"USD", 3, "3.00"},
+		{"BHD", noisy, "0.300"},
+		{"JPY", 1234.5, "1234"},
+	} {
+		m, _ := NewFromFloatRounded(test.curr, test.float)
+		if m.Exponent() != -int32(m.currency.Fraction) || m.StringFixed(int32(m.currency.Fraction)) != test.expected {
+			t.Errorf("%s %v: expected %s, got %s with exponent %d", test.curr, test.float, test.expected, m, m.Exponent())
+		}
+	}
+
+	if _, err := NewFromFloatRounded("I*am*Not*a*Currency", 1.23); err == nil {
+		t.Errorf("expected error for unknown currency")
+	}
+}
+
+func TestNewFromFloatCurrencyPrecision(t *testing.T) {
+	// Banker's rounding, even when the default is something else
+	defer func(mode RoundingMode) { DefaultRoundingMode = mode }(DefaultRoundingMode)
+	DefaultRoundingMode = RoundHalfUp
+
+	noisy := 0.1
+	noisy += 0.2
+	tests := []struct {
+		curr     string
+		float    float64
+		expected string
+	}{
+		{"USD", noisy, "0.30"},
+		{"USD", 2.665, "2.66"},
+		{"USD", 2.675, "2.68"},
+		{"USD", -2.665, "-2.66"},
+		{"USD", 3, "3.00"},
+		{"JPY", 1234.5, "1234"},
+		{"BHD", 1.23456, "1.235"},
+	}
+
+	for _, test := range tests {
+		m, err := NewFromFloatCurrencyPrecision(test.curr, test.float)
+		if err != nil {
+			t.Errorf("%s %v: unexpected error %s", test.curr, test.float, err)
+		} else if m.Exponent() != -int32(m.currency.Fraction) || m.StringFixed(int32(m.currency.Fraction)) != test.expected {
+			t.Errorf("%s %v: expected %s, got %s with exponent %d", test.curr, test.float, test.expected, m, m.Exponent())
+		}
+	}
+
+	if _, err := NewFromFloatCurrencyPrecision("I*am*Not*a*Currency", 1.23); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
 func TestNewFromFloatWithExponent(t *testing.T) {
 	type Inp struct {
 		float float64
@@ -567,6 +920,155 @@ func TestXML(t *testing.T) {
 	}
 }
 
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		m    Money
+		cell string
+	}{
+		{RequireFromString("USD", "1234567.89"), "USD 1234567.89"},
+		{RequireFromString("EUR", "-0.5"), "EUR -0.5"},
+		{RequireFromString("JPY", "100"), "JPY 100"},
+		{RequireFromString("KWD", "12.345"), "KWD 12.345"},
+		{RequireFromString("BTC", "0.00000001"), "BTC 0.00000001"},
+		{RequireFromString("USD", "1.23456789"), "USD 1.23456789"},
+		{RequireFromString("???", "7"), "7"},
+	}
+
+	for _, test := range tests {
+		cell := test.m.CSVCell()
+		if cell != test.cell {
+			t.Errorf("expected %q, got %q", test.cell, cell)
+		}
+
+		got, err := ParseCSVCell(cell)
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", cell, err)
+		} else if got.currency.Code != test.m.currency.Code || !got.Equal(test.m) {
+			t.Errorf("%q: expected %s %s, got %s %s", cell, test.m.currency, test.m, got.currency, got)
+		}
+	}
+
+	// Survives a trip through encoding/csv without any quoting
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"1", tests[0].m.CSVCell(), tests[3].m.CSVCell()})
+	w.Flush()
+	if buf.String() != "1,USD 1234567.89,KWD 12.345\n" {
+		t.Errorf("unexpected CSV %q", buf.String())
+	}
+
+	if _, err := ParseCSVCell("USD 12,34"); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse, got %v", err)
+	}
+	if _, err := ParseCSVCell("XXXX 1"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestXML_Currency(t *testing.T) {
+	type item struct {
+		XMLName xml.Name `xml:"item"`
+		Price   Money    `xml:"price,attr"`
+		Cost    Money    `xml:"cost"`
+	}
+
+	tests := []struct {
+		price Money
+		cost  Money
+		doc   string
+	}{
+		{RequireFromString("USD", "123.45"), RequireFromString("EUR", "-1.5"), `<item price="USD 123.45"><cost currency="EUR">-1.5</cost></item>`},
+		{RequireFromString("JPY", "100"), RequireFromString("???", "7"), `<item price="JPY 100"><cost>7</cost></item>`},
+	}
+
+	for _, test := range tests {
+		out, err := xml.Marshal(item{Price: test.price, Cost: test.cost})
+		if err != nil {
+			t.Errorf("error marshaling %s: %v", test.doc, err)
+		} else if string(out) != test.doc {
+			t.Errorf("expected %s, got %s", test.doc, out)
+		}
+
+		var got item
+		if err := xml.Unmarshal([]byte(test.doc), &got); err != nil {
+			t.Errorf("error unmarshaling %s: %v", test.doc, err)
+			continue
+		}
+		if got.Price.currency.Code != test.price.currency.Code || !got.Price.Equal(test.price) {
+			t.Errorf("%s: expected price %s %s, got %s %s", test.doc, test.price.currency, test.price, got.Price.currency, got.Price)
+		}
+		if got.Cost.currency.Code != test.cost.currency.Code || !got.Cost.Equal(test.cost) {
+			t.Errorf("%s: expected cost %s %s, got %s %s", test.doc, test.cost.currency, test.cost, got.Cost.currency, got.Cost)
+		}
+	}
+
+	var got item
+	err := xml.Unmarshal([]byte(`<item price="XXXX 1"><cost>1</cost></item>`), &got)
+	if !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestXML_Element(t *testing.T) {
+	type order struct {
+		XMLName xml.Name `xml:"order"`
+		ID      int      `xml:"id"`
+		Total   Money    `xml:"money"`
+		Tax     Money    `xml:"tax"`
+	}
+
+	tests := []struct {
+		in  order
+		doc string
+	}{
+		{order{ID: 1, Total: RequireFromString("USD", "123.45"), Tax: RequireFromString("USD", "12.3")},
+			`<order><id>1</id><money currency="USD">123.45</money><tax currency="USD">12.3</tax></order>`},
+		{order{ID: 2, Total: RequireFromString("BTC", "-0.00000001")},
+			`<order><id>2</id><money currency="BTC">-0.00000001</money><tax>0</tax></order>`},
+	}
+
+	for _, test := range tests {
+		out, err := xml.Marshal(test.in)
+		if err != nil {
+			t.Errorf("error marshaling %+v: %v", test.in, err)
+		} else if string(out) != test.doc {
+			t.Errorf("expected %s, got %s", test.doc, out)
+		}
+
+		var got order
+		if err := xml.Unmarshal([]byte(test.doc), &got); err != nil {
+			t.Errorf("error unmarshaling %s: %v", test.doc, err)
+			continue
+		}
+		if got.ID != test.in.ID || got.Total.Key() != test.in.Total.Key() || got.Tax.Key() != test.in.Tax.Key() {
+			t.Errorf("%s: expected %+v, got %+v", test.doc, test.in, got)
+		}
+	}
+
+	// Content written before the currency attribute existed
+	var got order
+	if err := xml.Unmarshal([]byte(`<order><money>EUR 10</money><tax>1</tax></order>`), &got); err != nil {
+		t.Errorf("unexpected error %s", err)
+	} else if got.Total.Key() != "EUR:10.00" || got.Tax.currency.Code != UnknownCurrencyCode {
+		t.Errorf("expected EUR 10 and ??? 1, got %s and %s", got.Total.Key(), got.Tax.Key())
+	}
+
+	badDocs := []struct {
+		doc  string
+		kind error
+	}{
+		{`<order><money currency="XXXX">1</money></order>`, ErrUnsupportedCurrency},
+		{`<order><money currency="USD">lots</money></order>`, ErrParse},
+		{`<order><money currency="USD"></money></order>`, ErrParse},
+	}
+	for _, test := range badDocs {
+		var got order
+		if err := xml.Unmarshal([]byte(test.doc), &got); !errors.Is(err, test.kind) {
+			t.Errorf("%s: expected %v, got %v", test.doc, test.kind, err)
+		}
+	}
+}
+
 func TestBadXML(t *testing.T) {
 	for _, testCase := range []string{
 		"o_o",
@@ -1297,6 +1799,45 @@ func TestDecimal_QuoRem2(t *testing.T) {
 	}
 }
 
+func TestMoney_DivMod(t *testing.T) {
+	tests := []struct {
+		m         string
+		divisor   string
+		quotient  int64
+		remainder string
+	}{
+		{"23.00", "5.00", 4, "3"},
+		{"20.00", "5.00", 4, "0"},
+		{"4.99", "5.00", 0, "4.99"},
+		{"-23.00", "5.00", -4, "-3"},
+		{"23.00", "-5.00", -4, "3"},
+		{"-23.00", "-5.00", 4, "-3"},
+		{"10.00", "0.25", 40, "0"},
+		{"10.10", "0.25", 40, "0.1"},
+		{"0", "5", 0, "0"},
+	}
+
+	for _, test := range tests {
+		q, r, err := RequireFromString("USD", test.m).DivMod(RequireFromString("USD", test.divisor))
+		if err != nil {
+			t.Errorf("%s DivMod %s: unexpected error %s", test.m, test.divisor, err)
+		} else if q != test.quotient || r.String() != test.remainder || r.currency.Code != "USD" {
+			t.Errorf("%s DivMod %s: expected (%d, %s) got (%d, %s %s)", test.m, test.divisor, test.quotient, test.remainder, q, r.currency, r)
+		}
+	}
+
+	m := RequireFromString("USD", "23")
+	if _, _, err := m.DivMod(RequireFromString("USD", "0")); err == nil {
+		t.Errorf("expected an error dividing by zero")
+	}
+	if _, _, err := m.DivMod(RequireFromString("EUR", "5")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, _, err := RequireFromString("USD", "1e30").DivMod(RequireFromString("USD", "1")); err == nil {
+		t.Errorf("expected an error when the count overflows")
+	}
+}
+
 // this is the old Div method from decimal
 // Div returns d / d2. If it doesn't divide exactly, the result will have
 // DivisionPrecision digits after the decimal point.
@@ -1535,6 +2076,46 @@ func BenchmarkDecimal_RoundCash_Fifteen(b *testing.B) {
 	}
 }
 
+func BenchmarkMoney_RoundCash(b *testing.B) {
+	m := RequireFromString("USD", "3.478")
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.RoundCash(5)
+	}
+}
+
+func BenchmarkMoney_RoundCashDecimal(b *testing.B) {
+	d := decimal.RequireFromString("3.478")
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		d.RoundCash(5)
+	}
+}
+
+func BenchmarkMoney_Truncate(b *testing.B) {
+	m := RequireFromString("USD", "3.478")
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.Truncate(2)
+	}
+}
+
+func BenchmarkMoney_Round(b *testing.B) {
+	m := RequireFromString("USD", "3.478")
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m.Round(2)
+	}
+}
+
 func TestDecimal_Mod(t *testing.T) {
 	type Inp struct {
 		a string
@@ -1651,25 +2232,129 @@ func TestIntPart(t *testing.T) {
 	}
 }
 
-func TestDecimal_Min(t *testing.T) {
-	// the first element in the array is the expected answer, rest are inputs
-	testCases := [][]float64{
-		{0, 0},
-		{1, 1},
-		{-1, -1},
-		{1, 1, 2},
-		{-2, 1, 2, -2},
-		{-3, 0, 2, -2, -3},
+func TestFracPart(t *testing.T) {
+	for _, testCase := range []struct {
+		Dec      string
+		IntPart  int64
+		FracPart string
+	}{
+		{"12.99", 12, "0.99"},
+		{"-12.99", -12, "-0.99"},
+		{"12", 12, "0"},
+		{"-12", -12, "0"},
+		{"0.01", 0, "0.01"},
+		{"-0.001", 0, "-0.001"},
+		{"9999.999", 9999, "0.999"},
+	} {
+		d := RequireFromString("USD", testCase.Dec)
+		if d.IntPart() != testCase.IntPart {
+			t.Errorf("%s: expect IntPart %d, got %d", testCase.Dec, testCase.IntPart, d.IntPart())
+		}
+		if d.FracPart().String() != testCase.FracPart {
+			t.Errorf("%s: expect FracPart %s, got %s", testCase.Dec, testCase.FracPart, d.FracPart())
+		}
+		if sum := decimal.New(d.IntPart(), 0).Add(d.FracPart()); !sum.Equal(d.amount) {
+			t.Errorf("%s: expect IntPart + FracPart to be the amount, got %s", testCase.Dec, sum)
+		}
 	}
+}
 
-	for _, test := range testCases {
-		expected, input := test[0], test[1:]
-		expectedDecimal, _ := NewFromFloat("???", expected)
-		decimalInput := []Money{}
-		for _, inp := range input {
-			d, _ := NewFromFloat("???", inp)
-			decimalInput = append(decimalInput, d)
-		}
+func TestZeroValueEqual(t *testing.T) {
+	var a, b Money
+
+	if !a.Equal(b) || a.Cmp(b) != 0 {
+		t.Errorf("expected zero value Moneys to be equal")
+	}
+	if !a.Equal(ZeroMoney) || !a.Equal(RequireFromString(UnknownCurrencyCode, "0.00")) {
+		t.Errorf("expected the zero value to equal ??? 0")
+	}
+
+	// Separate *Currency values with the same code are the same currency
+	c1 := RequireFromString("USD", "1.5")
+	c2 := RequireFromString("USD", "1.50").Clone()
+	if !c1.Equal(c2) {
+		t.Errorf("expected %s to equal %s", c1, c2)
+	}
+}
+
+func TestEqualAll(t *testing.T) {
+	tests := []struct {
+		ms       []Money
+		expected bool
+	}{
+		{nil, true},
+		{[]Money{RequireFromString("USD", "1")}, true},
+		{[]Money{RequireFromString("USD", "1.5"), RequireFromString("USD", "1.50"), RequireFromString("USD", "1.500").Clone()}, true},
+		{[]Money{RequireFromString("USD", "1.5"), RequireFromString("USD", "1.50"), RequireFromString("USD", "1.51")}, false},
+		{[]Money{RequireFromString("USD", "2"), RequireFromString("USD", "1.50"), RequireFromString("USD", "1.50")}, false},
+		{[]Money{{}, {}, ZeroMoney}, true},
+	}
+
+	for i, test := range tests {
+		if got := EqualAll(test.ms...); got != test.expected {
+			t.Errorf("case %d: expected %v got %v", i, test.expected, got)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected a panic for mismatched currencies")
+		}
+	}()
+	EqualAll(RequireFromString("USD", "1"), RequireFromString("USD", "1"), RequireFromString("EUR", "1"))
+}
+
+func TestFirstMismatch(t *testing.T) {
+	tests := []struct {
+		ms       []Money
+		index    int
+		found    bool
+		positive bool
+	}{
+		{nil, 0, false, true},
+		{usdAmounts("1"), 0, false, true},
+		{usdAmounts("1", "2.50", "0.01"), 0, false, true},
+		{usdAmounts("1", "-2.50", "0.01"), 0, false, false},
+		{usdAmounts("1", "0", "3"), 0, false, false},
+		{[]Money{RequireFromString("USD", "1"), RequireFromString("USD", "2"), RequireFromString("EUR", "3"), RequireFromString("GBP", "4")}, 2, true, true},
+		{[]Money{RequireFromString("EUR", "1"), RequireFromString("USD", "-2")}, 1, true, false},
+		{[]Money{RequireFromString("USD", "1"), {}}, 1, true, false},
+		{[]Money{{}, ZeroMoney}, 0, false, false},
+	}
+
+	for i, test := range tests {
+		index, found := FirstMismatch(test.ms)
+		if index != test.index || found != test.found {
+			t.Errorf("case %d: expected %d %t got %d %t", i, test.index, test.found, index, found)
+		}
+		if same := AllSameCurrency(test.ms); same != !test.found {
+			t.Errorf("case %d: expected AllSameCurrency %t got %t", i, !test.found, same)
+		}
+		if positive := AllPositive(test.ms); positive != test.positive {
+			t.Errorf("case %d: expected AllPositive %t got %t", i, test.positive, positive)
+		}
+	}
+}
+
+func TestDecimal_Min(t *testing.T) {
+	// the first element in the array is the expected answer, rest are inputs
+	testCases := [][]float64{
+		{0, 0},
+		{1, 1},
+		{-1, -1},
+		{1, 1, 2},
+		{-2, 1, 2, -2},
+		{-3, 0, 2, -2, -3},
+	}
+
+	for _, test := range testCases {
+		expected, input := test[0], test[1:]
+		expectedDecimal, _ := NewFromFloat("???", expected)
+		decimalInput := []Money{}
+		for _, inp := range input {
+			d, _ := NewFromFloat("???", inp)
+			decimalInput = append(decimalInput, d)
+		}
 		got := Min(decimalInput[0], decimalInput[1:]...)
 		if !got.Equal(expectedDecimal) {
 			t.Errorf("Expected %v, got %v, input=%+v", expectedDecimal, got,
@@ -1706,6 +2391,49 @@ func TestDecimal_Max(t *testing.T) {
 	}
 }
 
+func TestMinMaxValid(t *testing.T) {
+	bad, _ := NewFromString("XXXX", "1")
+	ms := []Money{
+		{},
+		RequireFromString("USD", "5"),
+		RequireFromString(UnknownCurrencyCode, "-100"),
+		RequireFromString("USD", "-2.50"),
+		bad,
+		RequireFromString("USD", "12"),
+		{},
+	}
+
+	lo, err := MinValid(ms...)
+	if err != nil || lo.String() != "-2.5" || lo.currency.Code != "USD" {
+		t.Errorf("expected USD -2.5, got %s %s (%v)", lo.currency, lo, err)
+	}
+
+	hi, err := MaxValid(ms...)
+	if err != nil || hi.String() != "12" || hi.currency.Code != "USD" {
+		t.Errorf("expected USD 12, got %s %s (%v)", hi.currency, hi, err)
+	}
+
+	// Ties keep the first
+	a, b := RequireFromString("USD", "1.5"), RequireFromString("USD", "1.50")
+	if got, _ := MinValid(Money{}, a, b); got.Exponent() != a.Exponent() {
+		t.Errorf("expected the first of equal Moneys, got %s", got)
+	}
+
+	for _, none := range [][]Money{nil, {}, {{}, {}}, {bad, RequireFromString(UnknownCurrencyCode, "1")}} {
+		if _, err := MinValid(none...); err == nil {
+			t.Errorf("%v: expected an error from MinValid", none)
+		}
+		if _, err := MaxValid(none...); err == nil {
+			t.Errorf("%v: expected an error from MaxValid", none)
+		}
+	}
+
+	mixed := []Money{RequireFromString("USD", "1"), {}, RequireFromString("EUR", "2")}
+	if _, err := MaxValid(mixed...); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+}
+
 func TestDecimal_Scan(t *testing.T) {
 	// test the Scan method that implements the
 	// sql.Scanner interface
@@ -1805,8 +2533,107 @@ func TestDecimal_Scan(t *testing.T) {
 
 	type foo struct{}
 	err = a.Scan(foo{})
-	if err == nil {
-		t.Errorf("a.Scan(Foo{}) should have thrown an error but did not")
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("a.Scan(Foo{}): expected ErrParse, got %v", err)
+	}
+}
+
+func TestDecimal_ScanNumeric(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		curr     string
+		expected string
+	}{
+		// More digits than a float64 can hold
+		{[]byte("12345678901234.56789"), "???", "12345678901234.56789"},
+		{"12345678901234.56789", "???", "12345678901234.56789"},
+		{[]byte("-0.000000000000000000000001"), "???", "-0.000000000000000000000001"},
+		{[]byte(`"99999999999999999.99"`), "???", "99999999999999999.99"},
+		{[]byte("USD 12345678901234.56789"), "USD", "12345678901234.56789"},
+		{"JPY 1000", "JPY", "1000"},
+		// Not a numeric, but read as a formatted amount. See TestDecimal_ScanFormatted
+		{"1,000.00", "???", "1000"},
+	}
+
+	for _, test := range tests {
+		var m Money
+		if err := m.Scan(test.value); err != nil {
+			t.Errorf("Scan(%q): unexpected error %s", test.value, err)
+		} else if m.currency.Code != test.curr || m.String() != test.expected {
+			t.Errorf("Scan(%q): expected %s %s, got %s %s", test.value, test.curr, test.expected, m.currency, m)
+		}
+	}
+
+	for _, value := range []interface{}{[]byte("12.34.56"), []byte(""), []byte("NaN"), []byte("USD abc")} {
+		var m Money
+		if err := m.Scan(value); !errors.Is(err, ErrParse) {
+			t.Errorf("Scan(%q): expected ErrParse, got %v", value, err)
+		}
+	}
+
+	var m Money
+	if err := m.Scan([]byte("XYZ 1.00")); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestDecimal_ScanFormatted(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		// As Postgres gives a money column
+		{"$1,234.56", "1234.56"},
+		{[]byte("-$1,234.56"), "-1234.56"},
+		{"($1,234.56)", "-1234.56"},
+		{"(1,234.56)", "-1234.56"},
+		{"1,000.00", "1000"},
+		{"$1234.56", "1234.56"},
+		{" $0.00 ", "0"},
+		{"$92,233,720,368,547,758.07", "92233720368547758.07"},
+	}
+
+	for _, test := range tests {
+		var m Money
+		if err := m.Scan(test.value); err != nil {
+			t.Errorf("Scan(%q): unexpected error %s", test.value, err)
+		} else if m.currency.Code != UnknownCurrencyCode || m.String() != test.expected {
+			t.Errorf("Scan(%q): expected ??? %s, got %s %s", test.value, test.expected, m.currency, m)
+		}
+	}
+
+	// Formatted, but not as the Formatter would, so they could be a different
+	// amount mangled
+	for _, value := range []interface{}{"1,000.00.00", "€1,000.00", "12,34", "1,2,3", "$1,2", "1,,000", ",000", "$1,000.", []byte("1234,567.00")} {
+		var m Money
+		if err := m.Scan(value); !errors.Is(err, ErrParse) {
+			t.Errorf("Scan(%q): expected ErrParse, got %s %v", value, m, err)
+		}
+	}
+
+	// With a default currency, it's parsed as that
+	SetDefaultCurrency("EUR-DE")
+	defer SetDefaultCurrency(UnknownCurrencyCode)
+
+	var m Money
+	if err := m.Scan("-1\u00a0234,56\u00a0€"); err != nil {
+		t.Errorf("unexpected error %s", err)
+	} else if m.currency.Code != "EUR-DE" || m.String() != "-1234.56" {
+		t.Errorf("expected EUR-DE -1234.56, got %s %s", m.currency, m)
+	}
+
+	// The groups have to be the default currency's
+	SetDefaultCurrency("INR")
+	if err := m.Scan("₹12,34,567.89"); err != nil || m.String() != "1234567.89" {
+		t.Errorf("expected INR 1234567.89, got %s %s (%v)", m.currency, m, err)
+	}
+	if err := m.Scan("₹1,234,567.89"); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse for western groups in INR, got %s %v", m, err)
+	}
+
+	// Plain amounts are still read as before
+	if err := m.Scan("1234.5"); err != nil || m.currency.Code != UnknownCurrencyCode || m.String() != "1234.5" {
+		t.Errorf("expected ??? 1234.5, got %s %s (%v)", m.currency, m, err)
 	}
 }
 
@@ -1828,6 +2655,68 @@ func TestDecimal_Value(t *testing.T) {
 	}
 }
 
+// textColumn stands in for a driver and a text column: it converts the value
+// the way database/sql does, stores it, and hands it back as bytes.
+type textColumn struct {
+	stored string
+}
+
+func (c *textColumn) Exec(v interface{}) error {
+	dv, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		return err
+	}
+	c.stored = dv.(string)
+	return nil
+}
+
+func (c *textColumn) Query(dest interface{ Scan(interface{}) error }) error {
+	return dest.Scan([]byte(c.stored))
+}
+
+func TestDecimal_ValueMode(t *testing.T) {
+	defer func(mode ValuerMode) { ValueMode = mode }(ValueMode)
+
+	tests := []struct {
+		mode     ValuerMode
+		m        Money
+		stored   string
+		expected string
+	}{
+		{ValueAmountOnly, RequireFromString("USD", "123.45"), "123.45", "??? 123.45"},
+		{ValueAmountOnly, RequireFromString("???", "-1.5"), "-1.5", "??? -1.5"},
+		{ValueComposite, RequireFromString("USD", "123.45"), "USD 123.45", "USD 123.45"},
+		{ValueComposite, RequireFromString("JPY", "-1000"), "JPY -1000", "JPY -1000"},
+		{ValueComposite, RequireFromString("???", "-1.5"), "-1.5", "??? -1.5"},
+	}
+
+	for _, test := range tests {
+		ValueMode = test.mode
+
+		var col textColumn
+		if err := col.Exec(test.m); err != nil {
+			t.Errorf("%d %s: unexpected error %s", test.mode, test.m, err)
+			continue
+		}
+		if col.stored != test.stored {
+			t.Errorf("%d %s: expected %q stored, got %q", test.mode, test.m, test.stored, col.stored)
+		}
+
+		var got Money
+		if err := col.Query(&got); err != nil {
+			t.Errorf("%d %s: unexpected error %s", test.mode, test.m, err)
+		} else if s := got.currency.Code + " " + got.String(); s != test.expected {
+			t.Errorf("%d %s: expected %s back, got %s", test.mode, test.m, test.expected, s)
+		}
+	}
+
+	// NullMoney follows suit
+	ValueMode = ValueComposite
+	if v, err := (NullMoney{Money: RequireFromString("EUR", "2"), Valid: true}).Value(); err != nil || v != "EUR 2" {
+		t.Errorf("expected EUR 2, got %v (%v)", v, err)
+	}
+}
+
 // old tests after this line
 
 func TestDecimal_Scale(t *testing.T) {
@@ -1932,383 +2821,2626 @@ func TestDecimal_Cmp2(t *testing.T) {
 	}
 }
 
-func TestPow(t *testing.T) {
-	a, _ := New("???", 4, 0)
-	b, _ := New("???", 2, 0)
-	x := a.Pow(b)
-	if x.String() != "16" {
-		t.Errorf("Error, saw %s", x.String())
+func TestDecimal_Compare(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		cmp      int
+		expected Ordering
+	}{
+		{"1.23", "12.34", -1, Less},
+		{"12.34", "12.340", 0, Equal},
+		{"12.34", "-1.23", 1, Greater},
 	}
-}
 
-func TestNegativePow(t *testing.T) {
-	a, _ := New("???", 4, 0)
-	b, _ := New("???", -2, 0)
-	x := a.Pow(b)
-	if x.String() != "0.0625" {
-		t.Errorf("Error, saw %s", x.String())
+	for _, test := range tests {
+		a := RequireFromString("USD", test.a)
+		b := RequireFromString("USD", test.b)
+
+		if a.Cmp(b) != test.cmp {
+			t.Errorf("%s Cmp %s: expected %d got %d", test.a, test.b, test.cmp, a.Cmp(b))
+		}
+		if got := a.Compare(b); got != test.expected {
+			t.Errorf("%s Compare %s: expected %s got %s", test.a, test.b, test.expected, got)
+		}
 	}
 }
 
-func TestDecimal_Sign(t *testing.T) {
-	if ZeroMoney.Sign() != 0 {
-		t.Errorf("%q should have sign 0", ZeroMoney)
+func TestDecimal_CompareMismatch(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected a panic comparing mismatched currencies")
+		}
+	}()
+
+	RequireFromString("USD", "1").Compare(RequireFromString("EUR", "1"))
+}
+
+func TestDecimal_CmpAbs(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"-10", "5", 1},
+		{"5", "-10", -1},
+		{"-5", "5", 0},
+		{"5.00", "-5", 0},
+		{"-0.01", "0", 1},
+		{"-3", "-4", -1},
 	}
 
-	one, _ := New("???", 1, 0)
-	if one.Sign() != 1 {
-		t.Errorf("%q should have sign 1", one)
+	for _, test := range tests {
+		a := RequireFromString("USD", test.a)
+		b := RequireFromString("USD", test.b)
+
+		if got := a.CmpAbs(b); got != test.expected {
+			t.Errorf("%s CmpAbs %s: expected %d got %d", test.a, test.b, test.expected, got)
+		}
 	}
 
-	mone, _ := New("???", -1, 0)
-	if mone.Sign() != -1 {
-		t.Errorf("%q should have sign -1", mone)
+	if !didPanic(func() { RequireFromString("USD", "1").CmpAbs(RequireFromString("EUR", "1")) }) {
+		t.Errorf("expected a panic comparing mismatched currencies")
 	}
 }
 
-func didPanic(f func()) bool {
-	ret := false
-	func() {
+func TestMoney_CompareAmount(t *testing.T) {
+	tests := []struct {
+		value     string
+		threshold string
+		eq        bool
+		gt        bool
+		gte       bool
+		lt        bool
+		lte       bool
+	}{
+		{"100.00", "100", true, false, true, false, true},
+		{"100.01", "100.00", false, true, true, false, false},
+		{"99.99", "100.00", false, false, false, true, true},
+		{"-0.01", "0", false, false, false, true, true},
+		{"100", " 100.000 ", true, false, true, false, true},
+	}
 
-		defer func() {
-			if message := recover(); message != nil {
-				ret = true
+	for _, test := range tests {
+		m := RequireFromString("USD", test.value)
+		for _, c := range []struct {
+			name     string
+			f        func(string) (bool, error)
+			expected bool
+		}{
+			{"EqualAmount", m.EqualAmount, test.eq},
+			{"GreaterThanAmount", m.GreaterThanAmount, test.gt},
+			{"GreaterThanOrEqualAmount", m.GreaterThanOrEqualAmount, test.gte},
+			{"LessThanAmount", m.LessThanAmount, test.lt},
+			{"LessThanOrEqualAmount", m.LessThanOrEqualAmount, test.lte},
+		} {
+			got, err := c.f(test.threshold)
+			if err != nil {
+				t.Errorf("%s %s(%s): unexpected error %s", test.value, c.name, test.threshold, err)
+			} else if got != c.expected {
+				t.Errorf("%s %s(%s): expected %v got %v", test.value, c.name, test.threshold, c.expected, got)
 			}
-		}()
+		}
+	}
 
-		// call the target function
-		f()
+	for _, bad := range []string{"", "abc", "$100", "1,000"} {
+		got, err := RequireFromString("USD", "100").GreaterThanAmount(bad)
+		if !errors.Is(err, ErrParse) || got {
+			t.Errorf("GreaterThanAmount(%q): expected false and ErrParse, got %v %v", bad, got, err)
+		}
+	}
+}
 
-	}()
+func TestMoney_AddSubMany(t *testing.T) {
+	a := RequireFromString("USD", "10.50")
+	b := RequireFromString("USD", "2.25")
+	c := RequireFromString("USD", "-0.75")
+	d := RequireFromString("USD", "100")
 
-	return ret
+	if got := a.AddMany(b, c, d); got.String() != "112" || got.currency.Code != "USD" {
+		t.Errorf("AddMany: expected USD 112, got %s %s", got.currency, got)
+	}
+	if got := a.SubMany(b, c, d); got.String() != "-91" {
+		t.Errorf("SubMany: expected -91, got %s", got)
+	}
+	if got := a.AddMany(); !got.Equal(a) {
+		t.Errorf("AddMany: expected %s with no arguments, got %s", a, got)
+	}
+	if got, expected := a.AddMany(b, c, d), a.Add(b).Add(c).Add(d); !got.Equal(expected) {
+		t.Errorf("AddMany: expected %s, got %s", expected, got)
+	}
 
+	for name, f := range map[string]func(){
+		"AddMany": func() { a.AddMany(b, RequireFromString("EUR", "1"), d) },
+		"SubMany": func() { a.SubMany(b, c, RequireFromString("EUR", "1")) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s: expected a panic for mismatched currencies", name)
+				}
+			}()
+			f()
+		}()
+	}
 }
 
-func TestDecimal_Coefficient(t *testing.T) {
-	d, _ := New("???", 123, 0)
-	co := d.Coefficient()
-	if co.Int64() != 123 {
-		t.Error("Coefficient should be 123; Got:", co)
+func TestMoney_IsSameSign(t *testing.T) {
+	tests := []struct {
+		a        Money
+		b        Money
+		expected bool
+	}{
+		{RequireFromString("USD", "1"), RequireFromString("USD", "100"), true},
+		{RequireFromString("USD", "-1"), RequireFromString("USD", "-0.01"), true},
+		{RequireFromString("USD", "0"), RequireFromString("USD", "0.00"), true},
+		{RequireFromString("USD", "1"), RequireFromString("USD", "-1"), false},
+		{RequireFromString("USD", "0"), RequireFromString("USD", "1"), false},
+		{RequireFromString("USD", "-1"), RequireFromString("USD", "0"), false},
+		{RequireFromString("USD", "5"), RequireFromString("EUR", "3"), true},
+		{RequireFromString("USD", "-5"), RequireFromString("JPY", "3"), false},
+		{Money{}, RequireFromString("EUR", "0"), true},
 	}
-	co.Set(big.NewInt(0))
-	if d.IntPart() != 123 {
-		t.Error("Modifying coefficient modified Decimal; Got:", d)
+
+	for _, test := range tests {
+		if got := test.a.IsSameSign(test.b); got != test.expected {
+			t.Errorf("%s %s IsSameSign %s %s: expected %v got %v", test.a.currency, test.a, test.b.currency, test.b, test.expected, got)
+		}
 	}
 }
 
-type MoneySlice []Money
+func TestMoney_EqualWith(t *testing.T) {
+	zeroes := EqualOptions{TreatZeroAsEqualAcrossCurrencies: true}
 
-func (p MoneySlice) Len() int           { return len(p) }
-func (p MoneySlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
-func (p MoneySlice) Less(i, j int) bool { return p[i].Cmp(p[j]) < 0 }
-func Benchmark_Cmp(b *testing.B) {
-	decimals := MoneySlice([]Money{})
-	for i := 0; i < 1000000; i++ {
-		foo, _ := New("???", int64(i), 0)
-		decimals = append(decimals, foo)
+	tests := []struct {
+		m1, m2   Money
+		opts     EqualOptions
+		expected bool
+	}{
+		{RequireFromString("USD", "0"), RequireFromString("EUR", "0"), EqualOptions{}, false},
+		{RequireFromString("USD", "0"), RequireFromString("EUR", "0.00"), zeroes, true},
+		{RequireFromString("USD", "-0"), RequireFromString("JPY", "0"), zeroes, true},
+		{RequireFromString("USD", "10"), RequireFromString("EUR", "10"), EqualOptions{}, false},
+		{RequireFromString("USD", "10"), RequireFromString("EUR", "10"), zeroes, false},
+		{RequireFromString("USD", "0"), RequireFromString("EUR", "10"), zeroes, false},
+		{RequireFromString("USD", "1.50"), RequireFromString("USD", "1.5"), EqualOptions{}, true},
+		{RequireFromString("USD", "1.50"), RequireFromString("USD", "1.51"), zeroes, false},
+		{RequireFromString("USD", "0"), RequireFromString("USD", "0"), EqualOptions{}, true},
 	}
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		sort.Sort(decimals)
+
+	for _, test := range tests {
+		var got bool
+		if didPanic(func() { got = test.m1.EqualWith(test.m2, test.opts) }) {
+			t.Errorf("%s %s vs %s %s: unexpected panic", test.m1.currency, test.m1, test.m2.currency, test.m2)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("%s %s vs %s %s (%+v): expected %t got %t", test.m1.currency, test.m1, test.m2.currency, test.m2, test.opts, test.expected, got)
+		}
 	}
 }
 
-func TestNullMoney_Scan(t *testing.T) {
-	// test the Scan method that implements the
-	// sql.Scanner interface
-	// check for the for different type of values
-	// that are possible to be received from the database
-	// drivers
-
-	// in normal operations the db driver (sqlite at least)
-	// will return an int64 if you specified a numeric format
+func TestMoney_IsInteger(t *testing.T) {
+	tests := []struct {
+		m        Money
+		integer  bool
+		subMinor bool
+	}{
+		{RequireFromString("USD", "1.00"), true, false},
+		{RequireFromString("USD", "1.50"), false, false},
+		{RequireFromString("USD", "1.005"), false, true},
+		{RequireFromString("USD", "1.500"), false, false},
+		{RequireFromString("USD", "-3"), true, false},
+		{RequireFromString("USD", "-0.001"), false, true},
+		{RequireFromString("USD", "1e3"), true, false},
+		{RequireFromString("USD", "0"), true, false},
+		{RequireFromString("JPY", "1.5"), false, true},
+		{RequireFromString("BHD", "1.005"), false, false},
+		{Money{}, true, false},
+	}
 
-	// Make sure handles nil values
-	a := NullMoney{}
-	var dbvaluePtr interface{}
-	err := a.Scan(dbvaluePtr)
-	if err != nil {
-		// Scan failed... no need to test result value
-		t.Errorf("a.Scan(nil) failed with message: %s", err)
-	} else {
-		if a.Valid {
-			t.Errorf("%s is not null", a.Money)
+	for _, test := range tests {
+		if got := test.m.IsInteger(); got != test.integer {
+			t.Errorf("%s %s: expected IsInteger %t got %t", test.m.currency, test.m, test.integer, got)
+		}
+		if got := test.m.HasFraction(); got == test.integer {
+			t.Errorf("%s %s: expected HasFraction %t got %t", test.m.currency, test.m, !test.integer, got)
+		}
+		if got := test.m.HasSubMinorUnits(); got != test.subMinor {
+			t.Errorf("%s %s: expected HasSubMinorUnits %t got %t", test.m.currency, test.m, test.subMinor, got)
 		}
 	}
+}
 
-	dbvalue := float64(54.33)
-	expected, _ := NewFromFloat("???", dbvalue)
-
-	err = a.Scan(dbvalue)
-	if err != nil {
-		// Scan failed... no need to test result value
-		t.Errorf("a.Scan(54.33) failed with message: %s", err)
+func TestMoney_CmpZero(t *testing.T) {
+	tests := []struct {
+		m       Money
+		cmp     int
+		greater bool
+		less    bool
+	}{
+		{RequireFromString("USD", "12.34"), 1, true, false},
+		{RequireFromString("JPY", "0.0001"), 1, true, false},
+		{RequireFromString("USD", "-0.01"), -1, false, true},
+		{RequireFromString("EUR", "0"), 0, false, false},
+		{RequireFromString("USD", "0.00"), 0, false, false},
+		{Money{}, 0, false, false},
+	}
 
-	} else {
-		// Scan succeeded... test resulting values
-		if !a.Valid {
-			t.Errorf("%s is null", a.Money)
-		} else if !a.Money.Equals(expected) {
-			t.Errorf("%s does not equal to %s", a.Money, expected)
+	for _, test := range tests {
+		if got := test.m.CmpZero(); got != test.cmp {
+			t.Errorf("%s %s CmpZero: expected %d got %d", test.m.currency, test.m, test.cmp, got)
+		}
+		if got := test.m.GreaterThanZero(); got != test.greater {
+			t.Errorf("%s %s GreaterThanZero: expected %v got %v", test.m.currency, test.m, test.greater, got)
+		}
+		if got := test.m.LessThanZero(); got != test.less {
+			t.Errorf("%s %s LessThanZero: expected %v got %v", test.m.currency, test.m, test.less, got)
 		}
 	}
+}
 
-	// at least SQLite returns an int64 when 0 is stored in the db
-	// and you specified a numeric format on the schema
-	dbvalueInt := int64(0)
-	expected, _ = New("???", dbvalueInt, 0)
+func TestMoney_FlipSign(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"1.5", "-1.5"},
+		{"-1.5", "1.5"},
+		{"0", "0"},
+	}
 
-	err = a.Scan(dbvalueInt)
+	for _, test := range tests {
+		m := RequireFromString("USD", test.value)
+		if got := m.FlipSign(); got.String() != test.expected || !got.Equal(m.Neg()) {
+			t.Errorf("%s: expected %s got %s", test.value, test.expected, got)
+		}
+	}
+}
+
+func TestMoney_SignString(t *testing.T) {
+	tests := []struct {
+		value     string
+		sign      string
+		magnitude string
+		custom    string
+	}{
+		{"12.50", "CR", "12.5", "debit"},
+		{"-12.50", "DR", "12.5", "credit"},
+		{"0", "ZERO", "0", "nil"},
+		{"-0.00", "ZERO", "0", "nil"},
+	}
+
+	custom := SignNames{Negative: "credit", Zero: "nil", Positive: "debit"}
+
+	for _, test := range tests {
+		m := RequireFromString("USD", test.value)
+		if got := m.SignString(); got != test.sign {
+			t.Errorf("%s: expected %s got %s", test.value, test.sign, got)
+		}
+		if got := m.Magnitude(); got.String() != test.magnitude || got.currency.Code != "USD" {
+			t.Errorf("%s: expected magnitude USD %s got %s %s", test.value, test.magnitude, got.currency, got)
+		}
+		if got := m.SignStringWith(custom); got != test.custom {
+			t.Errorf("%s: expected %s got %s", test.value, test.custom, got)
+		}
+	}
+
+	defer func(names SignNames) { DefaultSignNames = names }(DefaultSignNames)
+	DefaultSignNames = custom
+	if got := RequireFromString("USD", "1").SignString(); got != "debit" {
+		t.Errorf("expected DefaultSignNames to be used, got %s", got)
+	}
+}
+
+func TestMoney_MinMax(t *testing.T) {
+	tests := []struct {
+		a   string
+		b   string
+		min string
+		max string
+	}{
+		{"1.23", "12.34", "1.23", "12.34"},
+		{"12.34", "1.23", "1.23", "12.34"},
+		{"-5", "5", "-5", "5"},
+		{"7", "7", "7", "7"},
+	}
+
+	for _, test := range tests {
+		a := RequireFromString("USD", test.a)
+		b := RequireFromString("USD", test.b)
+
+		if got := a.Min(b); got.String() != test.min {
+			t.Errorf("%s Min %s: expected %s got %s", test.a, test.b, test.min, got)
+		}
+		if got := a.Max(b); got.String() != test.max {
+			t.Errorf("%s Max %s: expected %s got %s", test.a, test.b, test.max, got)
+		}
+	}
+}
+
+func TestMoney_Clamp(t *testing.T) {
+	tests := []struct {
+		m        string
+		lo       string
+		hi       string
+		expected string
+	}{
+		{"5", "1", "10", "5"},
+		{"-5", "1", "10", "1"},
+		{"50", "1", "10", "10"},
+		{"1", "1", "10", "1"},
+		{"10", "1", "10", "10"},
+		{"3", "7", "7", "7"},
+		{"7", "7", "7", "7"},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString("USD", test.m)
+		lo := RequireFromString("USD", test.lo)
+		hi := RequireFromString("USD", test.hi)
+
+		if got := m.Clamp(lo, hi); got.String() != test.expected {
+			t.Errorf("%s Clamp(%s, %s): expected %s got %s", test.m, test.lo, test.hi, test.expected, got)
+		}
+	}
+}
+
+func TestMoney_ClampPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		lo   Money
+		hi   Money
+	}{
+		{"lo > hi", RequireFromString("USD", "10"), RequireFromString("USD", "1")},
+		{"mismatched", RequireFromString("EUR", "1"), RequireFromString("EUR", "10")},
+	}
+
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s: expected a panic", test.name)
+				}
+			}()
+			RequireFromString("USD", "5").Clamp(test.lo, test.hi)
+		}()
+	}
+}
+
+func TestMoney_NoNegativeZero(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		op    func(Money) Money
+	}{
+		{"Abs", "-0.00", func(m Money) Money { return m.Abs() }},
+		{"Neg", "0", func(m Money) Money { return m.Neg() }},
+		{"Neg Neg", "-0.000", func(m Money) Money { return m.Neg().Neg() }},
+		{"Round", "-0.001", func(m Money) Money { return m.Round(2) }},
+		{"RoundBank", "-0.005", func(m Money) Money { return m.RoundBank(2) }},
+		{"RoundCash", "-0.02", func(m Money) Money { return m.RoundCash(5) }},
+		{"Floor", "-0", func(m Money) Money { return m.Floor() }},
+		{"Ceil", "-0.4", func(m Money) Money { return m.Ceil() }},
+		{"Truncate", "-0.009", func(m Money) Money { return m.Truncate(2) }},
+	}
+
+	for _, test := range tests {
+		got := test.op(RequireFromString("USD", test.value))
+		if got.Sign() != 0 {
+			t.Errorf("%s(%s): expected Sign 0, got %d", test.name, test.value, got.Sign())
+		}
+		if got.String() != "0" {
+			t.Errorf("%s(%s): expected \"0\", got %q", test.name, test.value, got.String())
+		}
+		if got.StringFixed(2) != "0.00" {
+			t.Errorf("%s(%s): expected \"0.00\", got %q", test.name, test.value, got.StringFixed(2))
+		}
+		if got.Coefficient().Sign() != 0 {
+			t.Errorf("%s(%s): expected a zero coefficient, got %s", test.name, test.value, got.Coefficient())
+		}
+	}
+}
+
+func TestUnsignedZero(t *testing.T) {
+	usd := MustGetCurrency("USD")
+
+	for _, d := range []decimal.Decimal{
+		decimal.New(0, -2).Neg(),
+		decimal.RequireFromString("-0.00"),
+		decimal.RequireFromString("-0"),
+		decimal.New(-1, -3).Mul(decimal.Zero),
+		decimal.New(-4, -3).Round(2),
+	} {
+		got := unsignedZero(d)
+		if got.Sign() != 0 || got.Coefficient().Sign() != 0 || got.Exponent() != d.Exponent() {
+			t.Errorf("%s: expected an unsigned zero with exponent %d, got %s (exp %d)", d, d.Exponent(), got, got.Exponent())
+		}
+		if got.String() != "0" || got.StringFixed(2) != "0.00" {
+			t.Errorf("%s: expected \"0\" and \"0.00\", got %q and %q", d, got.String(), got.StringFixed(2))
+		}
+
+		// And through the Money methods, starting from the signed zero itself
+		m := Money{amount: d, currency: usd}
+		for name, op := range map[string]func(Money) Money{
+			"Abs":       Money.Abs,
+			"Neg":       Money.Neg,
+			"Round":     func(m Money) Money { return m.Round(2) },
+			"RoundCash": func(m Money) Money { return m.RoundCash(5) },
+			"Truncate":  func(m Money) Money { return m.Truncate(2) },
+		} {
+			if r := op(m); r.Sign() != 0 || r.String() != "0" || r.StringFixed(2) != "0.00" || r.FormattedStringBank() != "$0.00" {
+				t.Errorf("%s(%s): expected an unsigned zero, got %q %q", name, d, r.String(), r.FormattedStringBank())
+			}
+		}
+	}
+
+	// Anything else is left alone
+	if d := decimal.New(-1, -2); !unsignedZero(d).Equal(d) {
+		t.Errorf("expected -0.01 unchanged, got %s", unsignedZero(d))
+	}
+}
+
+func TestMoney_WithCurrency(t *testing.T) {
+	usd := RequireFromString("USD", "10.505")
+
+	eur, err := usd.WithCurrency("EUR")
 	if err != nil {
-		// Scan failed... no need to test result value
-		t.Errorf("a.Scan(0) failed with message: %s", err)
+		t.Fatalf("unexpected error %s", err)
+	}
+	if eur.currency.Code != "EUR" || eur.String() != "10.505" || eur.Exponent() != usd.Exponent() {
+		t.Errorf("expected EUR 10.505, got %s %s", eur.currency, eur)
+	}
+	if usd.currency.Code != "USD" {
+		t.Errorf("expected the original to stay USD, got %s", usd.currency)
+	}
 
-	} else {
-		// Scan succeeded... test resulting values
-		if !a.Valid {
-			t.Errorf("%s is null", a.Money)
-		} else if !a.Money.Equals(expected) {
-			t.Errorf("%v does not equal %v", a, expected)
+	// Unlike UpdateCurrency, it doesn't matter what the currency was
+	if jpy, err := eur.WithCurrency("JPY"); err != nil || jpy.currency.Code != "JPY" || jpy.String() != "10.505" {
+		t.Errorf("expected JPY 10.505, got %s %s (%v)", jpy.currency, jpy, err)
+	}
+	if m, err := (Money{}).WithCurrency("GBP"); err != nil || m.currency.Code != "GBP" || m.String() != "0" {
+		t.Errorf("expected GBP 0, got %s %s (%v)", m.currency, m, err)
+	}
+
+	if _, err := usd.WithCurrency("XXXX"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestMoney_Clone(t *testing.T) {
+	AddCurrency(FIAT, "CLN", "c", "$1", ".", ",", 2)
+	defer delete(currencies, "CLN")
+
+	shared := RequireFromString("CLN", "1.234")
+	clone := shared.Clone()
+
+	if clone.String() != shared.String() || clone.currency == shared.currency || *clone.currency != *shared.currency {
+		t.Errorf("expected an equal clone with its own currency, got %s %+v", clone, clone.currency)
+	}
+
+	// Changing the registered currency in place shows up in the shared Money only
+	MustGetCurrency("CLN").Fraction = 3
+	if shared.FormattedStringBank() != "c1.234" {
+		t.Errorf("expected shared Money to follow the registry, got %s", shared.FormattedStringBank())
+	}
+	if clone.FormattedStringBank() != "c1.23" {
+		t.Errorf("expected clone to be unaffected, got %s", clone.FormattedStringBank())
+	}
+
+	// AddCurrency replaces the entry, so neither sees it, but new Moneys do
+	AddCurrency(FIAT, "CLN", "C", "$1", ".", ",", 0)
+	if shared.FormattedStringBank() != "c1.234" || clone.FormattedStringBank() != "c1.23" {
+		t.Errorf("expected AddCurrency not to touch existing Moneys, got %s and %s", shared.FormattedStringBank(), clone.FormattedStringBank())
+	}
+	if got := RequireFromString("CLN", "1.234").FormattedStringBank(); got != "C1" {
+		t.Errorf("expected new Money to use the new currency, got %s", got)
+	}
+
+	// Still the same currency as far as the maths is concerned
+	if !clone.Equal(shared) {
+		t.Errorf("expected clone to equal the original")
+	}
+}
+
+func TestAllowUnknownCurrencyOps(t *testing.T) {
+	defer func(allow bool) { AllowUnknownCurrencyOps = allow }(AllowUnknownCurrencyOps)
+
+	var scanned Money
+	if err := scanned.Scan("10"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	usd := RequireFromString("USD", "2.50")
+
+	// Off by default: ??? + USD panics, ??? + ??? doesn't
+	AllowUnknownCurrencyOps = false
+	if !didPanic(func() { scanned.Add(usd) }) {
+		t.Errorf("expected ??? + USD to panic")
+	}
+	if got := scanned.Add(scanned); got.currency.Code != UnknownCurrencyCode || got.String() != "20" {
+		t.Errorf("expected ??? 20, got %s %s", got.currency, got)
+	}
+
+	AllowUnknownCurrencyOps = true
+	tests := []struct {
+		name     string
+		got      Money
+		expected string
+	}{
+		{"??? + USD", scanned.Add(usd), "12.5"},
+		{"USD + ???", usd.Add(scanned), "12.5"},
+		{"??? - USD", scanned.Sub(usd), "7.5"},
+		{"zero value + USD", Money{}.Add(usd), "2.5"},
+	}
+	for _, test := range tests {
+		if test.got.currency.Code != "USD" || test.got.String() != test.expected {
+			t.Errorf("%s: expected USD %s, got %s %s", test.name, test.expected, test.got.currency, test.got)
+		}
+	}
+	if !scanned.GreaterThan(usd) {
+		t.Errorf("expected ??? 10 > USD 2.50")
+	}
+
+	// Known currencies still can't be mixed
+	if !didPanic(func() { usd.Add(RequireFromString("EUR", "1")) }) {
+		t.Errorf("expected USD + EUR to panic")
+	}
+}
+
+func TestMoney_FormatBankStatement(t *testing.T) {
+	tests := []struct {
+		curr     string
+		value    string
+		expected string
+	}{
+		{"USD", "-1234.56", "1,234.56 DR"},
+		{"USD", "1234.56", "1,234.56 CR"},
+		{"USD", "0", "0.00"},
+		{"USD", "-0.001", "0.00"},
+		{"EUR-DE", "-1234567.891", "1\u00a0234\u00a0567,89 DR"},
+		{"JPY", "500", "500 CR"},
+	}
+
+	for _, test := range tests {
+		if got := RequireFromString(test.curr, test.value).FormatBankStatement(); got != test.expected {
+			t.Errorf("%s %s: expected %q got %q", test.curr, test.value, test.expected, got)
+		}
+	}
+}
+
+func TestMoney_FormatAccountingDash(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"0", "\u2014"},
+		{"0.004", "\u2014"},
+		{"-0.004", "\u2014"},
+		{"1234.5", "1234.50"},
+		{"-1234.5", "(1234.50)"},
+	}
+
+	for _, test := range tests {
+		if got := RequireFromString("USD", test.value).FormatAccountingDash(); got != test.expected {
+			t.Errorf("%s: expected %q got %q", test.value, test.expected, got)
+		}
+	}
+
+	if got := RequireFromString("USD", "0").FormattedStringAccounting(); got != "0.00" {
+		t.Errorf("expected FormattedStringAccounting to be unchanged, got %q", got)
+	}
+}
+
+func TestMoney_FormattedStringFixedCash(t *testing.T) {
+	tests := []struct {
+		amount   string
+		interval uint8
+		expected string
+	}{
+		{"3.43", 5, "$3.45"},
+		{"3.45", 10, "$3.50"},
+		{"3.45", 15, "$3.40"},
+		{"1234.41", 25, "$1,234.50"},
+		{"-3.75", 50, "-$4.00"},
+		{"3.49999", 100, "$3.00"},
+	}
+
+	for _, test := range tests {
+		if s := RequireFromString("AUD", test.amount).FormattedStringFixedCash(test.interval); s != test.expected {
+			t.Errorf("%s at %d: expected %s, got %s", test.amount, test.interval, test.expected, s)
+		}
+	}
+}
+
+func TestMoney_FormattedStringMode(t *testing.T) {
+	m := RequireFromString("USD", "2.125")
+
+	if got := m.FormattedStringMode(RoundHalfEven); got != m.FormattedStringBank() || got != "$2.12" {
+		t.Errorf("expected $2.12 to match FormattedStringBank, got %s", got)
+	}
+	if got := m.FormattedStringMode(RoundHalfUp); got != "$2.13" {
+		t.Errorf("expected $2.13, got %s", got)
+	}
+}
+
+func TestMoney_FormatParts(t *testing.T) {
+	tests := []struct {
+		curr     string
+		amount   string
+		expected [4]string
+	}{
+		{"USD", "-1234.56", [4]string{"-", "$", "1,234", "56"}},
+		{"USD", "1234.5", [4]string{"", "$", "1,234", "50"}},
+		{"USD", "-0.001", [4]string{"", "$", "0", "00"}},
+		{"USD", "1234567.125", [4]string{"", "$", "1,234,567", "12"}},
+		{"JPY", "1234", [4]string{"", "\u00a5", "1,234", ""}},
+		{"EUR-DE", "-1234.56", [4]string{"-", "\u20ac", "1\u00a0234", "56"}},
+		{"INR", "1234567.8", [4]string{"", "\u20b9", "12,34,567", "80"}},
+	}
+
+	for _, test := range tests {
+		sign, symbol, integer, fraction := RequireFromString(test.curr, test.amount).FormatParts()
+		if got := [4]string{sign, symbol, integer, fraction}; got != test.expected {
+			t.Errorf("%s %s: expected %q got %q", test.curr, test.amount, test.expected, got)
+		}
+	}
+}
+
+func TestMoney_FormatPadded(t *testing.T) {
+	tests := []struct {
+		curr       string
+		value      string
+		width      int
+		expected   string
+		accounting string
+	}{
+		{"USD", "5", 12, "       $5.00", "       5.00 "},
+		{"USD", "1234.56", 12, "   $1,234.56", "    1234.56 "},
+		{"USD", "-1234.56", 12, "  -$1,234.56", "   (1234.56)"},
+		{"EUR", "5", 12, "       \u20ac5.00", "       5.00 "},
+		{"EUR-DE", "1234.56", 12, "  1\u00a0234,56\u00a0\u20ac", "    1234,56 "},
+		{"USD", "1234567.89", 5, "$1,234,567.89", "1234567.89 "},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString(test.curr, test.value)
+
+		got := m.FormatPadded(test.width)
+		if got != test.expected {
+			t.Errorf("%s %s: expected %q got %q", test.curr, test.value, test.expected, got)
+		}
+		if n := utf8.RuneCountInString(got); n < test.width {
+			t.Errorf("%s %s: expected at least %d runes, got %d", test.curr, test.value, test.width, n)
+		}
+
+		if got := m.FormatAccountingPadded(test.width); got != test.accounting {
+			t.Errorf("%s %s: expected accounting %q got %q", test.curr, test.value, test.accounting, got)
+		}
+	}
+}
+
+func TestMoney_StringWithCode(t *testing.T) {
+	tests := []struct {
+		curr     string
+		value    string
+		expected string
+	}{
+		{"USD", "1234.56", "USD 1,234.56"},
+		{"USD", "-1234.56", "-USD 1,234.56"},
+		{"USD", "0.5", "USD 0.50"},
+		{"EUR", "1234567.891", "EUR 1,234,567.89"},
+		{"JPY", "1234", "JPY 1,234"},
+	}
+
+	for _, test := range tests {
+		if got := RequireFromString(test.curr, test.value).StringWithCode(); got != test.expected {
+			t.Errorf("%s %s: expected %q got %q", test.curr, test.value, test.expected, got)
+		}
+	}
+}
+
+func TestMoney_FormatWithGrapheme(t *testing.T) {
+	tests := []struct {
+		m        Money
+		grapheme string
+		expected string
+	}{
+		{RequireFromString("USD", "1234.56"), "US$", "US$1,234.56"},
+		{RequireFromString("USD", "-1234.56"), "US$", "-US$1,234.56"},
+		{RequireFromString("USD", "1234.56"), "", "$1,234.56"},
+		{RequireFromString("CAD", "1234.56"), "CA$", "CA$1,234.56"},
+		{RequireFromString("EUR-DE", "1234.56"), "EUR", "1\u00a0234,56\u00a0EUR"},
+	}
+
+	for _, test := range tests {
+		if got := test.m.FormatWithGrapheme(test.grapheme); got != test.expected {
+			t.Errorf("%s %s with %q: expected %q got %q", test.m.currency, test.m, test.grapheme, test.expected, got)
+		}
+	}
+
+	// The registry is left alone
+	if c, _ := GetCurrency("USD"); c.Grapheme != "$" {
+		t.Errorf("expected the USD grapheme to still be $, got %s", c.Grapheme)
+	}
+	if got := RequireFromString("USD", "1").FormattedStringBank(); got != "$1.00" {
+		t.Errorf("expected $1.00, got %s", got)
+	}
+}
+
+func TestMoney_FormatTrimmed(t *testing.T) {
+	tests := []struct {
+		curr     string
+		value    string
+		expected string
+	}{
+		{"BTC", "0.50000000", "\u20bf0.5"},
+		{"BTC", "1.23450000", "\u20bf1.2345"},
+		{"BTC", "1234567.00000001", "\u20bf1,234,567.00000001"},
+		{"BTC", "0.000000001", "\u20bf0"},
+		{"BTC", "-2.10", "-\u20bf2.1"},
+		{"USD", "100.00", "$100"},
+		{"USD", "1234.50", "$1,234.5"},
+		{"USD", "0.125", "$0.12"},
+		{"EUR-DE", "1234.50", "1\u00a0234,5\u00a0\u20ac"},
+	}
+
+	for _, test := range tests {
+		if got := RequireFromString(test.curr, test.value).FormatTrimmed(); got != test.expected {
+			t.Errorf("%s %s: expected %q got %q", test.curr, test.value, test.expected, got)
+		}
+	}
+}
+
+func TestMoney_FloorCeilFraction(t *testing.T) {
+	tests := []struct {
+		curr  string
+		value string
+		floor string
+		ceil  string
+	}{
+		{"USD", "1.2349", "1.23", "1.24"},
+		{"USD", "-1.2349", "-1.24", "-1.23"},
+		{"USD", "1.23", "1.23", "1.23"},
+		{"USD", "-0.001", "-0.01", "0"},
+		{"JPY", "123.4", "123", "124"},
+		{"JPY", "-123.4", "-124", "-123"},
+		{"BTC", "0.123456789", "0.12345678", "0.12345679"},
+		{"BTC", "1", "1", "1"},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString(test.curr, test.value)
+		if got := m.FloorFraction(); got.String() != test.floor {
+			t.Errorf("%s %s FloorFraction: expected %s got %s", test.curr, test.value, test.floor, got)
+		}
+		if got := m.CeilFraction(); got.String() != test.ceil {
+			t.Errorf("%s %s CeilFraction: expected %s got %s", test.curr, test.value, test.ceil, got)
+		}
+	}
+}
+
+func TestMoney_FloorCeilTo(t *testing.T) {
+	tests := []struct {
+		value  string
+		places int32
+		floor  string
+		ceil   string
+	}{
+		{"1.2349", 3, "1.234", "1.235"},
+		{"1.2349", 0, "1", "2"},
+		{"-1.2349", 1, "-1.3", "-1.2"},
+		{"1234.5", -2, "1200", "1300"},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString("USD", test.value)
+		if got := m.FloorTo(test.places); got.String() != test.floor {
+			t.Errorf("%s FloorTo(%d): expected %s got %s", test.value, test.places, test.floor, got)
+		}
+		if got := m.CeilTo(test.places); got.String() != test.ceil {
+			t.Errorf("%s CeilTo(%d): expected %s got %s", test.value, test.places, test.ceil, got)
+		}
+	}
+}
+
+func TestMoney_AmountString(t *testing.T) {
+	tests := []struct {
+		curr     string
+		value    string
+		expected string
+	}{
+		{"USD", "1.5", "1.50"},
+		{"USD", "-1234567.891", "-1234567.89"},
+		{"USD", "0.125", "0.12"},
+		{"JPY", "1234", "1234"},
+		{"JPY", "1234.5", "1234"},
+		{"BTC", "0.5", "0.50000000"},
+		{"BTC", "1.123456789", "1.12345679"},
+		{"BHD", "1", "1.000"},
+	}
+
+	for _, test := range tests {
+		if got := RequireFromString(test.curr, test.value).AmountString(); got != test.expected {
+			t.Errorf("%s %s: expected %s got %s", test.curr, test.value, test.expected, got)
+		}
+	}
+}
+
+func TestMoney_RoundCashInterval(t *testing.T) {
+	tests := []struct {
+		value    string
+		interval uint8
+		expected string
+	}{
+		{"3.29", 20, "3.2"},
+		{"3.30", 20, "3.4"},
+		{"3.31", 20, "3.4"},
+		{"3.50", 20, "3.6"},
+		{"3.51", 20, "3.6"},
+		{"-3.31", 20, "-3.4"},
+		{"0.09", 20, "0"},
+		{"3.43", 5, "3.45"},
+		{"3.45", 10, "3.5"},
+		{"3.45", 15, "3.4"},
+		{"3.46", 15, "3.5"},
+		{"-3.45", 15, "-3.4"},
+		{"3.41", 25, "3.5"},
+		{"3.75", 50, "4"},
+		{"3.50", 100, "4"},
+	}
+
+	for _, test := range tests {
+		got, err := RequireFromString("AUD", test.value).RoundCashInterval(test.interval)
+		if err != nil {
+			t.Errorf("%s (%d): unexpected error %s", test.value, test.interval, err)
+		} else if got.String() != test.expected {
+			t.Errorf("%s (%d): expected %s got %s", test.value, test.interval, test.expected, got)
+		}
+	}
+
+	for _, interval := range []uint8{0, 1, 2, 30, 200} {
+		got, err := RequireFromString("AUD", "3.43").RoundCashInterval(interval)
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("%d: expected ErrInvalidArgument, got %s (%v)", interval, got, err)
+		} else if got.String() != "3.43" {
+			t.Errorf("%d: expected the amount back unchanged, got %s", interval, got)
+		}
+	}
+}
+
+func TestMoney_RoundCashMatchesDecimal(t *testing.T) {
+	// What RoundCash used to do, for every interval but 15
+	reference := func(d decimal.Decimal, interval uint8) decimal.Decimal {
+		k := decimal.New(100/int64(interval), 0)
+		return unsignedZero(d.Mul(k).Round(0).Div(k).Truncate(2))
+	}
+
+	values := []string{
+		"0", "0.01", "0.024", "0.025", "0.026", "3.43", "3.425", "3.45", "3.4999999",
+		"3.5", "3.75", "3.949", "348", "-0.025", "-3.43", "-3.45", "-3.75",
+		"1234567.895", "0.000000000000000001", "92233720368547758.07",
+		"-92233720368547758.075", "123456789012345678901234567890.125", "1e5", "-2.5e3",
+	}
+
+	for _, interval := range []uint8{5, 10, 20, 25, 50, 100} {
+		for _, v := range values {
+			d := decimal.RequireFromString(v)
+			want := reference(d, interval)
+
+			got, err := Money{amount: d, currency: getUnknownCurrency()}.RoundCashInterval(interval)
+			if err != nil {
+				t.Errorf("%s (%d): unexpected error %s", v, interval, err)
+			} else if !got.amount.Equal(want) || got.amount.Exponent() != want.Exponent() {
+				t.Errorf("%s (%d): expected %s (exp %d) got %s (exp %d)", v, interval, want, want.Exponent(), got.amount, got.amount.Exponent())
+			}
+		}
+	}
+
+	// 15 is 10 cent rounding with halves towards zero, which the decimal
+	// package doesn't do the same way in every version, so it's spelled out
+	for _, test := range []struct {
+		value    string
+		expected string
+	}{
+		{"6.35", "6.30"}, {"6.351", "6.40"}, {"6.36", "6.40"}, {"6.349", "6.30"}, {"6.30", "6.30"},
+		{"-6.35", "-6.30"}, {"-6.351", "-6.40"}, {"-0.05", "0.00"}, {"666", "666.00"},
+		{"123456789012345678901234567890.05", "123456789012345678901234567890.00"},
+	} {
+		got := RequireFromString("USD", test.value).RoundCash(15)
+		if got.amount.StringFixed(2) != test.expected || got.amount.Exponent() != -2 {
+			t.Errorf("%s (15): expected %s got %s (exp %d)", test.value, test.expected, got.amount, got.amount.Exponent())
+		}
+	}
+
+	if !didPanic(func() { RequireFromString("USD", "1").RoundCash(20) }) {
+		t.Error("expected RoundCash(20) to panic, as it's only supported by RoundCashInterval")
+	}
+}
+
+func TestMoney_RoundCashWithAdjustment(t *testing.T) {
+	tests := []struct {
+		value      string
+		interval   uint8
+		rounded    string
+		adjustment string
+	}{
+		{"3.43", 5, "3.45", "0.02"},
+		{"3.42", 5, "3.4", "-0.02"},
+		{"3.45", 5, "3.45", "0"},
+		{"-3.43", 5, "-3.45", "-0.02"},
+		{"3.29", 20, "3.2", "-0.09"},
+		{"3.50", 100, "4", "0.5"},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString("USD", test.value)
+		rounded, adj, err := m.RoundCashWithAdjustment(test.interval)
+		if err != nil {
+			t.Errorf("%s (%d): unexpected error %s", test.value, test.interval, err)
+			continue
+		}
+		if rounded.String() != test.rounded || adj.String() != test.adjustment || adj.currency.Code != "USD" {
+			t.Errorf("%s (%d): expected %s and %s, got %s and %s %s", test.value, test.interval, test.rounded, test.adjustment, rounded, adj.currency, adj)
+		}
+		if !m.Add(adj).Equal(rounded) {
+			t.Errorf("%s (%d): %s + %s isn't %s", test.value, test.interval, m, adj, rounded)
+		}
+	}
+
+	rounded, adj, err := RequireFromString("USD", "3.43").RoundCashWithAdjustment(7)
+	if err == nil || rounded.String() != "3.43" || adj.String() != "0" {
+		t.Errorf("expected an error, 3.43 and 0, got %s and %s (%v)", rounded, adj, err)
+	}
+}
+
+func TestMoney_RoundCashDefault(t *testing.T) {
+	tests := []struct {
+		curr     string
+		value    string
+		expected string
+	}{
+		{"CHF", "3.43", "3.45"},
+		{"CHF", "3.42", "3.4"},
+		{"CHF", "-3.475", "-3.5"},
+		{"CHF", "10", "10"},
+		{"USD", "3.43", "3.43"},
+		{"USD", "3.4321", "3.4321"},
+		{"JPY", "123", "123"},
+	}
+
+	for _, test := range tests {
+		if got := RequireFromString(test.curr, test.value).RoundCashDefault(); got.String() != test.expected {
+			t.Errorf("%s %s: expected %s got %s", test.curr, test.value, test.expected, got)
+		}
+	}
+
+	AddCurrencyFull(Currency{Code: "#20", Fraction: 2, CashInterval: 20})
+	defer delete(currencies, "#20")
+	if got := RequireFromString("#20", "3.31").RoundCashDefault(); got.String() != "3.4" {
+		t.Errorf("expected 3.4, got %s", got)
+	}
+}
+
+func TestMoney_RoundLikeCurrency(t *testing.T) {
+	tests := []struct {
+		amount   string
+		like     string
+		expected string
+	}{
+		{"1234.567", "JPY", "1235"},
+		{"1234.5678", "BHD", "1234.568"},
+		{"1234.5", "BHD", "1234.5"},
+		{"2.5", "JPY", "2"},
+		{"-1.5", "JPY", "-2"},
+		{"-0.4", "JPY", "0"},
+		{"0.0005", "BHD", "0"},
+		{"9.999", "EUR", "10"},
+	}
+
+	for _, test := range tests {
+		got, err := RequireFromString("USD", test.amount).RoundLikeCurrency(test.like)
+		if err != nil {
+			t.Errorf("%s like %s: unexpected error %s", test.amount, test.like, err)
+		} else if got.String() != test.expected || got.currency.Code != "USD" {
+			t.Errorf("%s like %s: expected USD %s got %s %s", test.amount, test.like, test.expected, got.currency, got)
+		}
+	}
+
+	m := RequireFromString("USD", "1.234")
+	got, err := m.RoundLikeCurrency("XXXX")
+	if !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+	if got.currency.Code != BadCurrencyCode || got.Sign() != 0 {
+		t.Errorf("expected %s 0, got %s %s", BadCurrencyCode, got.currency, got)
+	}
+}
+
+func TestMoney_RoundToNearest(t *testing.T) {
+	tests := []struct {
+		amount    string
+		increment string
+		expected  string
+	}{
+		{"3.43", "0.25", "3.5"},
+		{"3.37", "0.25", "3.25"},
+		{"127", "5", "125"},
+		{"128", "5", "130"},
+		{"-127", "5", "-125"},
+		{"0.12", "5", "0"},
+		{"10.00", "0.25", "10"},
+		// Halfway goes to the even multiple
+		{"2.5", "5", "0"},
+		{"7.5", "5", "10"},
+		{"0.30", "0.20", "0.4"},
+	}
+
+	for _, test := range tests {
+		got, err := RequireFromString("USD", test.amount).RoundToNearest(RequireFromString("USD", test.increment))
+		if err != nil {
+			t.Errorf("%s to %s: unexpected error %s", test.amount, test.increment, err)
+		} else if got.String() != test.expected || got.currency.Code != "USD" {
+			t.Errorf("%s to %s: expected USD %s, got %s %s", test.amount, test.increment, test.expected, got.currency, got)
+		}
+	}
+
+	m := RequireFromString("USD", "3.43")
+	for _, inc := range []string{"0", "-0.25"} {
+		if _, err := m.RoundToNearest(RequireFromString("USD", inc)); err == nil {
+			t.Errorf("%s: expected an error", inc)
+		}
+	}
+	if _, err := m.RoundToNearest(RequireFromString("EUR", "0.25")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+}
+
+func TestMoney_SnapToEnding(t *testing.T) {
+	tests := []struct {
+		curr     string
+		amount   string
+		ending   int
+		dir      SnapDirection
+		expected string
+	}{
+		{"USD", "12.40", 99, SnapDown, "11.99"},
+		{"USD", "12.40", 95, SnapDown, "11.95"},
+		{"USD", "12.99", 99, SnapDown, "12.99"},
+		{"USD", "12.995", 99, SnapDown, "12.99"},
+		{"USD", "-12.40", 99, SnapDown, "-13.01"},
+		{"USD", "12.40", 0, SnapDown, "12"},
+		{"USD", "12.40", 99, SnapUp, "12.99"},
+		{"USD", "12.99", 99, SnapUp, "12.99"},
+		{"USD", "12.40", 95, SnapNearest, "11.95"},
+		{"USD", "12.60", 99, SnapNearest, "12.99"},
+		{"USD", "12.49", 99, SnapNearest, "11.99"},
+		{"KWD", "7.5", 990, SnapDown, "6.99"},
+	}
+
+	for _, test := range tests {
+		got, err := RequireFromString(test.curr, test.amount).SnapToEndingWith(test.ending, test.dir)
+		if err != nil {
+			t.Errorf("%s %s to %d: unexpected error %s", test.curr, test.amount, test.ending, err)
+		} else if got.String() != test.expected || got.currency.Code != test.curr {
+			t.Errorf("%s %s to %d (%d): expected %s, got %s %s", test.curr, test.amount, test.ending, test.dir, test.expected, got.currency, got)
+		}
+	}
+
+	if got, err := RequireFromString("USD", "12.40").SnapToEnding(99); err != nil || got.String() != "11.99" {
+		t.Errorf("expected 11.99, got %s (%v)", got, err)
+	}
+
+	for _, test := range []struct {
+		curr   string
+		ending int
+	}{
+		{"USD", 100},
+		{"USD", -1},
+		{"JPY", 99},
+		{"JPY", 0},
+	} {
+		if _, err := RequireFromString(test.curr, "12").SnapToEnding(test.ending); err == nil {
+			t.Errorf("%s %d: expected an error", test.curr, test.ending)
+		}
+	}
+}
+
+func TestMoney_DivOpts(t *testing.T) {
+	defer func(p int) { DivisionPrecision = p }(DivisionPrecision)
+	DivisionPrecision = 20
+
+	tests := []struct {
+		a        string
+		b        string
+		opts     []DivOption
+		expected string
+	}{
+		{"2", "3", nil, "0.66666666666666666667"},
+		{"2", "3", []DivOption{WithPrecision(2)}, "0.67"},
+		{"1", "8", []DivOption{WithPrecision(2)}, "0.13"},
+		{"1", "8", []DivOption{WithPrecision(2), WithRoundingMode(RoundHalfEven)}, "0.12"},
+		{"-1", "8", []DivOption{WithPrecision(2), WithRoundingMode(RoundHalfEven)}, "-0.12"},
+		{"2", "3", []DivOption{WithRoundingMode(RoundDown)}, "0.66666666666666666666"},
+		{"2", "3", []DivOption{WithPrecision(0), WithRoundingMode(RoundUp)}, "1"},
+		{"-1", "3", []DivOption{WithPrecision(1), WithRoundingMode(RoundFloor)}, "-0.4"},
+		{"10", "4", []DivOption{WithPrecision(0)}, "3"},
+		{"10", "4", []DivOption{WithPrecision(0), WithPrecision(1)}, "2.5"},
+	}
+
+	for _, test := range tests {
+		got := RequireFromString("USD", test.a).DivOpts(RequireFromString("USD", test.b), test.opts...)
+		if got.String() != test.expected {
+			t.Errorf("%s / %s: expected %s got %s", test.a, test.b, test.expected, got)
+		}
+	}
+
+	if DivisionPrecision != 20 {
+		t.Errorf("expected DivisionPrecision to be untouched, got %d", DivisionPrecision)
+	}
+
+	// The global still applies to Div, and to DivOpts without WithPrecision
+	DivisionPrecision = 3
+	a, b := RequireFromString("USD", "2"), RequireFromString("USD", "3")
+	if got := a.Div(b).String(); got != "0.667" {
+		t.Errorf("Div: expected 0.667, got %s", got)
+	}
+	if got := a.DivOpts(b).String(); got != "0.667" {
+		t.Errorf("DivOpts: expected 0.667, got %s", got)
+	}
+	if got := a.DivOpts(b, WithPrecision(5)).String(); got != "0.66667" {
+		t.Errorf("DivOpts: expected 0.66667, got %s", got)
+	}
+}
+
+func TestMoney_RoundTruncateChecked(t *testing.T) {
+	tests := []struct {
+		value     string
+		places    int32
+		round     string
+		roundLost bool
+		trunc     string
+		truncLost bool
+	}{
+		{"1.50", 1, "1.5", false, "1.5", false},
+		{"1.2300", 2, "1.23", false, "1.23", false},
+		{"1.55", 1, "1.6", true, "1.5", true},
+		{"1.239", 2, "1.24", true, "1.23", true},
+		{"-1.231", 2, "-1.23", true, "-1.23", true},
+		{"100", 0, "100", false, "100", false},
+		{"0.001", 2, "0", true, "0", true},
+		{"125", -1, "130", true, "", false},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString("USD", test.value)
+
+		r, lost := m.RoundChecked(test.places)
+		if r.String() != test.round || lost != test.roundLost || r.currency.Code != "USD" {
+			t.Errorf("%s RoundChecked(%d): expected %s (lost %v) got %s %s (lost %v)", test.value, test.places, test.round, test.roundLost, r.currency, r, lost)
+		}
+
+		// Truncate needs a precision >= 0
+		if test.places < 0 {
+			continue
+		}
+		r, lost = m.TruncateChecked(test.places)
+		if r.String() != test.trunc || lost != test.truncLost || r.currency.Code != "USD" {
+			t.Errorf("%s TruncateChecked(%d): expected %s (lost %v) got %s %s (lost %v)", test.value, test.places, test.trunc, test.truncLost, r.currency, r, lost)
+		}
+	}
+}
+
+func TestMoney_TruncateToFraction(t *testing.T) {
+	tests := []struct {
+		curr     string
+		value    string
+		expected string
+	}{
+		{"USD", "1.999", "1.99"},
+		{"USD", "-1.999", "-1.99"},
+		{"USD", "1.9", "1.9"},
+		{"USD", "-0.009", "0"},
+		{"JPY", "123.99", "123"},
+		{"BTC", "0.123456789", "0.12345678"},
+		{"BTC", "1", "1"},
+	}
+
+	for _, test := range tests {
+		if got := RequireFromString(test.curr, test.value).TruncateToFraction(); got.String() != test.expected {
+			t.Errorf("%s %s: expected %s got %s", test.curr, test.value, test.expected, got)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	a, _ := New("???", 4, 0)
+	b, _ := New("???", 2, 0)
+	x := a.Pow(b)
+	if x.String() != "16" {
+		t.Errorf("Error, saw %s", x.String())
+	}
+}
+
+func TestNegativePow(t *testing.T) {
+	a, _ := New("???", 4, 0)
+	b, _ := New("???", -2, 0)
+	x := a.Pow(b)
+	if x.String() != "0.0625" {
+		t.Errorf("Error, saw %s", x.String())
+	}
+}
+
+func TestDecimal_Sign(t *testing.T) {
+	if ZeroMoney.Sign() != 0 {
+		t.Errorf("%q should have sign 0", ZeroMoney)
+	}
+
+	one, _ := New("???", 1, 0)
+	if one.Sign() != 1 {
+		t.Errorf("%q should have sign 1", one)
+	}
+
+	mone, _ := New("???", -1, 0)
+	if mone.Sign() != -1 {
+		t.Errorf("%q should have sign -1", mone)
+	}
+}
+
+func didPanic(f func()) bool {
+	ret := false
+	func() {
+
+		defer func() {
+			if message := recover(); message != nil {
+				ret = true
+			}
+		}()
+
+		// call the target function
+		f()
+
+	}()
+
+	return ret
+
+}
+
+func TestDecimal_Coefficient(t *testing.T) {
+	d, _ := New("???", 123, 0)
+	co := d.Coefficient()
+	if co.Int64() != 123 {
+		t.Error("Coefficient should be 123; Got:", co)
+	}
+	co.Set(big.NewInt(0))
+	if d.IntPart() != 123 {
+		t.Error("Modifying coefficient modified Decimal; Got:", d)
+	}
+}
+
+func Benchmark_Cmp(b *testing.B) {
+	decimals := MoneySlice([]Money{})
+	for i := 0; i < 1000000; i++ {
+		foo, _ := New("???", int64(i), 0)
+		decimals = append(decimals, foo)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sort.Sort(decimals)
+	}
+}
+
+func TestNullMoney_Scan(t *testing.T) {
+	// test the Scan method that implements the
+	// sql.Scanner interface
+	// check for the for different type of values
+	// that are possible to be received from the database
+	// drivers
+
+	// in normal operations the db driver (sqlite at least)
+	// will return an int64 if you specified a numeric format
+
+	// Make sure handles nil values
+	a := NullMoney{}
+	var dbvaluePtr interface{}
+	err := a.Scan(dbvaluePtr)
+	if err != nil {
+		// Scan failed... no need to test result value
+		t.Errorf("a.Scan(nil) failed with message: %s", err)
+	} else {
+		if a.Valid {
+			t.Errorf("%s is not null", a.Money)
+		}
+	}
+
+	dbvalue := float64(54.33)
+	expected, _ := NewFromFloat("???", dbvalue)
+
+	err = a.Scan(dbvalue)
+	if err != nil {
+		// Scan failed... no need to test result value
+		t.Errorf("a.Scan(54.33) failed with message: %s", err)
+
+	} else {
+		// Scan succeeded... test resulting values
+		if !a.Valid {
+			t.Errorf("%s is null", a.Money)
+		} else if !a.Money.Equals(expected) {
+			t.Errorf("%s does not equal to %s", a.Money, expected)
+		}
+	}
+
+	// at least SQLite returns an int64 when 0 is stored in the db
+	// and you specified a numeric format on the schema
+	dbvalueInt := int64(0)
+	expected, _ = New("???", dbvalueInt, 0)
+
+	err = a.Scan(dbvalueInt)
+	if err != nil {
+		// Scan failed... no need to test result value
+		t.Errorf("a.Scan(0) failed with message: %s", err)
+
+	} else {
+		// Scan succeeded... test resulting values
+		if !a.Valid {
+			t.Errorf("%s is null", a.Money)
+		} else if !a.Money.Equals(expected) {
+			t.Errorf("%v does not equal %v", a, expected)
+		}
+	}
+
+	// in case you specified a varchar in your SQL schema,
+	// the database driver will return byte slice []byte
+	valueStr := "535.666"
+	dbvalueStr := []byte(valueStr)
+	expected, err = NewFromString("???", valueStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.Scan(dbvalueStr)
+	if err != nil {
+		// Scan failed... no need to test result value
+		t.Errorf("a.Scan('535.666') failed with message: %s", err)
+
+	} else {
+		// Scan succeeded... test resulting values
+		if !a.Valid {
+			t.Errorf("%s is null", a.Money)
+		} else if !a.Money.Equals(expected) {
+			t.Errorf("%v does not equal %v", a, expected)
+		}
+	}
+
+	// lib/pq can also return strings
+	expected, err = NewFromString("???", valueStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.Scan(valueStr)
+	if err != nil {
+		// Scan failed... no need to test result value
+		t.Errorf("a.Scan('535.666') failed with message: %s", err)
+	} else {
+		// Scan succeeded... test resulting values
+		if !a.Valid {
+			t.Errorf("%s is null", a.Money)
+		} else if !a.Money.Equals(expected) {
+			t.Errorf("%v does not equal %v", a, expected)
+		}
+	}
+}
+
+func TestNullMoney_Value(t *testing.T) {
+	// Make sure this does implement the database/sql's driver.Valuer interface
+	// check that normal case is handled appropriately
+	nm1, _ := New("???", 1234, -2)
+
+	a := NullMoney{Money: nm1, Valid: true}
+
+	var NullMoney NullMoney
+	if _, ok := interface{}(NullMoney).(driver.Valuer); !ok {
+		t.Error("NullMoney does not implement driver.Valuer")
+	}
+
+	// check that null is handled appropriately
+	value, err := NullMoney.Value()
+	if err != nil {
+		t.Errorf("NullMoney{}.Valid() failed with message: %s", err)
+	} else if value != nil {
+		t.Errorf("%v is not nil", value)
+	}
+
+	expected := "12.34"
+	value, err = a.Value()
+	if err != nil {
+		t.Errorf("NullMoney(12.34).Value() failed with message: %s", err)
+	} else if value.(string) != expected {
+		t.Errorf("%v does not equal %v", a, expected)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	for _, y := range testTable {
+		x := y.float
+
+		// Create the decimal
+		d1, _ := NewFromFloat("???", x)
+
+		// Encode to binary
+		b, err := d1.MarshalBinary()
+		if err != nil {
+			t.Errorf("error marshalling %v to binary: %v", d1, err)
+		}
+		// Restore from binary
+		var d2 Money
+		err = (&d2).UnmarshalBinary(b)
+		if err != nil {
+			t.Errorf("error unmarshalling from binary: %v", err)
+		}
+
+		// The restored decimal should equal the original
+		if !d1.Equals(d2) {
+			t.Errorf("expected %v when restoring, got %v", d1, d2)
+		}
+	}
+}
+
+func TestBinary_Versions(t *testing.T) {
+	AddCurrencyFull(Currency{Type: POINTS, Code: "LONGCODE", Fraction: 2})
+	defer delete(currencies, "LONGCODE")
+
+	coef, err := big.NewInt(-12345).GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []byte{0xff, 0xff, 0xff, 0xfe} // -2
+
+	// Written before there was a version byte
+	legacy := append(append([]byte("USD"), exp...), coef...)
+
+	// The same, tagged as version 0
+	tagged := append([]byte{0}, legacy...)
+
+	v1 := append([]byte{1, 8}, "LONGCODE"...)
+	v1 = append(append(v1, exp...), coef...)
+
+	tests := []struct {
+		data []byte
+		curr string
+	}{
+		{legacy, "USD"},
+		{tagged, "USD"},
+		{v1, "LONGCODE"},
+	}
+
+	for _, test := range tests {
+		var m Money
+		if err := m.UnmarshalBinary(test.data); err != nil {
+			t.Errorf("%s: unexpected error %s", test.curr, err)
+		} else if m.currency.Code != test.curr || m.String() != "-123.45" {
+			t.Errorf("%s: expected -123.45, got %s %s", test.curr, m.currency, m)
+		}
+	}
+
+	// New blobs are written as version 1, and codes needn't be 3 bytes
+	b, err := RequireFromString("LONGCODE", "-123.45").MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !bytes.Equal(b, v1) {
+		t.Errorf("expected %v, got %v", v1, b)
+	}
+
+	// Re-encoding a legacy blob upgrades it
+	var m Money
+	m.UnmarshalBinary(legacy)
+	if b, _ := m.MarshalBinary(); b[0] != 1 || string(b[2:5]) != "USD" {
+		t.Errorf("expected a version 1 USD blob, got %v", b)
+	}
+
+	// The code's length has to fit in its byte
+	huge := RequireFromString("USD", "1")
+	huge.currency = &Currency{Code: strings.Repeat("X", math.MaxUint8+1)}
+	if _, err := huge.MarshalBinary(); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+
+	for _, data := range [][]byte{nil, {0}, {1}, {1, 8, 'L'}, legacy[:7], tagged[:8], {2, 3, 'U', 'S', 'D', 0, 0, 0, 0, 0}} {
+		if err := m.UnmarshalBinary(data); !errors.Is(err, ErrParse) {
+			t.Errorf("%v: expected ErrParse, got %v", data, err)
+		}
+	}
+}
+
+func slicesEqual(a, b []byte) bool {
+	for i, val := range a {
+		if b[i] != val {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGobEncode(t *testing.T) {
+	for _, y := range testTable {
+		x := y.float
+		d1, _ := NewFromFloat("???", x)
+
+		b1, err := d1.GobEncode()
+		if err != nil {
+			t.Errorf("error encoding %v to binary: %v", d1, err)
+		}
+
+		d2, _ := NewFromFloat("???", x)
+
+		b2, err := d2.GobEncode()
+		if err != nil {
+			t.Errorf("error encoding %v to binary: %v", d2, err)
+		}
+
+		if !slicesEqual(b1, b2) {
+			t.Errorf("something about the gobencode is not working properly \n%v\n%v", b1, b2)
+		}
+
+		var d3 Money
+		err = d3.GobDecode(b1)
+		if err != nil {
+			t.Errorf("Error gobdecoding %v, got %v", b1, d3)
+		}
+		var d4 Money
+		err = d4.GobDecode(b2)
+		if err != nil {
+			t.Errorf("Error gobdecoding %v, got %v", b2, d4)
+		}
+
+		eq := d3.Equal(d4)
+		if eq != true {
+			t.Errorf("Encoding then decoding mutated Decimal")
+		}
+
+		eq = d1.Equal(d3)
+		if eq != true {
+			t.Errorf("Error gobencoding/decoding %v, got %v", d1, d3)
+		}
+	}
+}
+
+func TestSum(t *testing.T) {
+	vals := make([]Money, 10)
+	var i = int64(0)
+
+	for key := range vals {
+		vals[key], _ = New("???", i, 0)
+		i++
+	}
+
+	sum := Sum(vals[0], vals[1:]...)
+	tm1, _ := New("???", 45, 0)
+	if !sum.Equal(tm1) {
+		t.Errorf("Failed to calculate sum, expected %s got %s", tm1, sum)
+	}
+}
+
+func TestAvg(t *testing.T) {
+	vals := make([]Money, 10)
+	var i = int64(0)
+
+	for key := range vals {
+		vals[key], _ = New("???", i, 0)
+		i++
+	}
+
+	avg := Avg(vals[0], vals[1:]...)
+	check, _ := NewFromFloat("???", 4.5)
+	if !avg.Equal(check) {
+		t.Errorf("Failed to calculate average, expected %s got %s", check, avg.String())
+	}
+}
+
+func TestRoundBankAnomaly(t *testing.T) {
+	a, _ := New("???", 25, -1)
+	b, _ := New("???", 250, -2)
+
+	if !a.Equal(b) {
+		t.Errorf("Expected %s to equal %s", a, b)
+	}
+
+	expected, _ := New("???", 2, 0)
+
+	aRounded := a.RoundBank(0)
+	if !aRounded.Equal(expected) {
+		t.Errorf("Expected bank rounding %s to equal %s, but it was %s", a, expected, aRounded)
+	}
+
+	bRounded := b.RoundBank(0)
+	if !bRounded.Equal(expected) {
+		t.Errorf("Expected bank rounding %s to equal %s, but it was %s", b, expected, bRounded)
+	}
+}
+
+func TestFormattedStrings(t *testing.T) {
+	for _, x := range testTable {
+		s := x.exact
+		aud := x.asAUD
+		d, err := NewFromString("AUD", s)
+		if err != nil {
+			t.Errorf("error while parsing %s", s)
+		} else if aud != "N/A" && d.FormattedStringBank() != aud {
+			t.Errorf("expected %s, got %s (from: %s)",
+				aud, d.FormattedStringBank(),
+				d.String())
+		}
+	}
+
+	for _, x := range testTable {
+		s := x.exact
+		acc := x.asAUDAccounting
+		d, err := NewFromString("AUD", s)
+		if err != nil {
+			t.Errorf("error while parsing %s", s)
+		} else {
+			d = d.Neg()
+			if acc != "N/A" && d.FormattedStringAccounting() != acc {
+				if acc == "0.00" && d.FormattedStringAccounting() == "(0.00)" {
+					// special case - we cool
+				} else {
+					t.Errorf("expected %s, got %s (from: %s)",
+						acc, d.FormattedStringAccounting(),
+						d.String())
+				}
+			}
+		}
+	}
+
+}
+
+func TestAvg_RoundsToFraction(t *testing.T) {
+	tests := []struct {
+		curr     string
+		values   []string
+		expected string
+	}{
+		{"USD", []string{"10", "20"}, "15.00"},
+		{"USD", []string{"10", "20", "70"}, "33.33"},
+		{"USD", []string{"0.01", "0.02"}, "0.02"},
+		{"USD", []string{"0.03", "0.04"}, "0.04"},
+		{"USD", []string{"-10", "-20", "-70"}, "-33.33"},
+		{"USD", []string{"9.99", "9.99", "9.99"}, "9.99"},
+		{"JPY", []string{"100", "101"}, "100"},
+	}
+
+	for _, test := range tests {
+		vals := make([]Money, len(test.values))
+		for i, v := range test.values {
+			vals[i] = RequireFromString(test.curr, v)
+		}
+
+		avg := Avg(vals[0], vals[1:]...)
+		if avg.StringFixed(int32(avg.currency.Fraction)) != test.expected {
+			t.Errorf("Avg of %v %s: expected %s got %s", test.values, test.curr, test.expected, avg)
+		}
+	}
+}
+
+func TestAvg_SingleElement(t *testing.T) {
+	m := RequireFromString("USD", "10")
+	avg := Avg(m)
+	if !avg.Equal(m) || avg.Exponent() != m.Exponent() {
+		t.Errorf("Expected average of single element %s to be unchanged, got %s", m, avg)
+	}
+
+	m = RequireFromString("USD", "10.005")
+	avg = Avg(m)
+	if !avg.Equal(m) || avg.Exponent() != m.Exponent() {
+		t.Errorf("Expected average of single element %s to be unchanged, got %s", m, avg)
+	}
+}
+
+func TestMoney_Apply(t *testing.T) {
+	// Newton's method, good enough for a test
+	sqrt := func(d decimal.Decimal) decimal.Decimal {
+		x := d
+		two := decimal.New(2, 0)
+		for i := 0; i < 20 && x.Sign() != 0; i++ {
+			x = x.Add(d.DivRound(x, 20)).DivRound(two, 20)
+		}
+		return x.Round(8)
+	}
+
+	if got := RequireFromString("USD", "2.25").Apply(sqrt); got.String() != "1.5" || got.currency.Code != "USD" {
+		t.Errorf("expected USD 1.5, got %s %s", got.currency, got)
+	}
+	if got := RequireFromString("EUR", "2").Apply(sqrt); got.String() != "1.41421356" || got.currency.Code != "EUR" {
+		t.Errorf("expected EUR 1.41421356, got %s %s", got.currency, got)
+	}
+
+	markup := func(d decimal.Decimal) decimal.Decimal {
+		return d.Mul(decimal.RequireFromString("1.2")).Add(decimal.New(5, 0))
+	}
+	if got := RequireFromString("USD", "100").Apply(markup); got.String() != "125" {
+		t.Errorf("expected 125, got %s", got)
+	}
+
+	// Works on the zero value, and doesn't touch the original
+	if got := (Money{}).Apply(markup); got.String() != "5" || got.currency.Code != UnknownCurrencyCode {
+		t.Errorf("expected ??? 5, got %s %s", got.currency, got)
+	}
+	m := RequireFromString("USD", "1")
+	m.Apply(markup)
+	if m.String() != "1" {
+		t.Errorf("expected the original to be 1, got %s", m)
+	}
+
+	// No negative zero
+	if got := RequireFromString("USD", "1").Apply(func(d decimal.Decimal) decimal.Decimal { return d.Mul(decimal.Zero).Neg() }); got.Sign() != 0 || got.String() != "0" {
+		t.Errorf("expected 0, got %s", got)
+	}
+}
+
+func TestMoney_Sqrt(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected string
+	}{
+		{"16", "4"},
+		{"2.25", "1.5"},
+		{"0.0001", "0.01"},
+		{"0", "0"},
+		{"2", "1.4142135623730950488"},
+		{"3", "1.73205080756887729353"},
+		{"1e40", "100000000000000000000"},
+		{"0.00000000000000000000000000000000000000000001", "0"},
+	}
+
+	for _, test := range tests {
+		got, err := RequireFromString("USD", test.amount).Sqrt()
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", test.amount, err)
+		} else if got.String() != test.expected || got.currency.Code != "USD" {
+			t.Errorf("%s: expected USD %s got %s %s", test.amount, test.expected, got.currency, got)
+		}
+	}
+
+	m := RequireFromString("USD", "-4")
+	if got, err := m.Sqrt(); err == nil || !got.Equal(m) {
+		t.Errorf("expected an error and -4 back, got %s (%v)", got, err)
+	}
+}
+
+func TestMoney_DecimalOp(t *testing.T) {
+	errOp := errors.New("no can do")
+	m := RequireFromString("EUR", "10")
+
+	got, err := m.DecimalOp(func(d decimal.Decimal) (decimal.Decimal, error) {
+		return d.Mul(d), nil
+	})
+	if err != nil || got.String() != "100" || got.currency.Code != "EUR" {
+		t.Errorf("expected EUR 100, got %s %s (%v)", got.currency, got, err)
+	}
+
+	got, err = m.DecimalOp(func(d decimal.Decimal) (decimal.Decimal, error) {
+		return decimal.Zero, errOp
+	})
+	if !errors.Is(err, errOp) || !got.Equal(m) || got.currency.Code != "EUR" {
+		t.Errorf("expected the op's error and EUR 10 back, got %s %s (%v)", got.currency, got, err)
+	}
+}
+
+func TestDecimal_MulScalar(t *testing.T) {
+	m := RequireFromString("USD", "9.99")
+
+	if c := m.MulScalar(decimal.New(3, 0)); c.String() != "29.97" || c.currency.Code != "USD" {
+		t.Errorf("expected USD 29.97, got %s %s", c.currency, c)
+	}
+	if c := m.MulInt(3); c.String() != "29.97" {
+		t.Errorf("expected 29.97, got %s", c)
+	}
+	if c := m.MulScalar(decimal.New(-15, -1)); c.String() != "-14.985" {
+		t.Errorf("expected -14.985, got %s", c)
+	}
+}
+
+func TestMoney_StrictDimensions(t *testing.T) {
+	usd := RequireFromString("USD", "3")
+	qty := RequireFromString(UnknownCurrencyCode, "4")
+
+	// Allowed by default
+	if c := usd.Mul(RequireFromString("USD", "4")); c.String() != "12" {
+		t.Errorf("expected 12, got %s", c)
+	}
+
+	StrictDimensions = true
+	AllowUnknownCurrencyOps = true
+	defer func() {
+		StrictDimensions = false
+		AllowUnknownCurrencyOps = false
+	}()
+
+	if !didPanic(func() { usd.Mul(RequireFromString("USD", "4")) }) {
+		t.Errorf("expected panic multiplying USD by USD")
+	}
+	if !didPanic(func() { usd.Mul(RequireFromString("EUR", "4")) }) {
+		t.Errorf("expected panic multiplying USD by EUR")
+	}
+
+	// Scaling by a plain quantity is still fine
+	if c := usd.MulScalar(decimal.New(4, 0)); c.String() != "12" || c.currency.Code != "USD" {
+		t.Errorf("expected USD 12, got %s %s", c.currency, c)
+	}
+	if c := usd.MulInt(4); c.String() != "12" {
+		t.Errorf("expected 12, got %s", c)
+	}
+
+	for _, c := range []Money{usd.Mul(qty), qty.Mul(usd)} {
+		if c.String() != "12" || c.currency.Code != "USD" {
+			t.Errorf("expected USD 12, got %s %s", c.currency, c)
+		}
+	}
+}
+
+func TestDecimal_DivScalar(t *testing.T) {
+	m := RequireFromString("USD", "10.00")
+
+	// Doesn't divide exactly, so we get DivisionPrecision places
+	if c := m.DivScalar(decimal.New(3, 0)); c.String() != "3.33333333333333333333" || c.currency.Code != "USD" {
+		t.Errorf("expected USD 3.33333333333333333333, got %s %s", c.currency, c)
+	}
+	if c := RequireFromString("USD", "20.00").DivInt(3); c.String() != "6.66666666666666666667" {
+		t.Errorf("expected 6.66666666666666666667, got %s", c)
+	}
+	if c := m.DivInt(4); c.String() != "2.5" {
+		t.Errorf("expected 2.5, got %s", c)
+	}
+
+	if !didPanic(func() { m.DivInt(0) }) {
+		t.Errorf("expected panic when dividing by zero")
+	}
+}
+
+func TestMoney_Rounded(t *testing.T) {
+	ten := RequireFromString("USD", "10.00")
+	three := decimal.New(3, 0)
+
+	if c := ten.DivRounded(three); c.String() != "3.33" || c.currency.Code != "USD" {
+		t.Errorf("expected USD 3.33, got %s %s", c.currency, c)
+	}
+	if c := RequireFromString("USD", "-20.00").DivRounded(three); c.String() != "-6.67" {
+		t.Errorf("expected -6.67, got %s", c)
+	}
+	if c := RequireFromString("JPY", "1000").DivRounded(three); c.String() != "333" {
+		t.Errorf("expected 333, got %s", c)
+	}
+
+	// Half even by default
+	if c := RequireFromString("USD", "2.50").MulRounded(decimal.RequireFromString("0.05")); c.String() != "0.12" {
+		t.Errorf("expected 0.12, got %s", c)
+	}
+	if c := RequireFromString("USD", "1.005").AddRounded(RequireFromString("USD", "0.01")); c.String() != "1.02" {
+		t.Errorf("expected 1.02, got %s", c)
+	}
+	if c := RequireFromString("USD", "1.005").SubRounded(RequireFromString("USD", "1.01")); c.String() != "0" {
+		t.Errorf("expected 0, got %s", c)
+	}
+
+	// The precise versions are untouched
+	if c := ten.DivScalar(three); c.String() != "3.33333333333333333333" {
+		t.Errorf("expected 3.33333333333333333333, got %s", c)
+	}
+
+	// Three rounded thirds lose a cent, Allocate doesn't
+	third := ten.DivRounded(three)
+	if s := Sum(third, third, third); s.Equal(ten) {
+		t.Errorf("expected rounded thirds not to add up to 10.00, got %s", s)
+	}
+	parts := ten.Allocate(1, 1, 1)
+	if s := Sum(parts[0], parts[1:]...); !s.Equal(ten) {
+		t.Errorf("expected allocated parts to add up to 10.00, got %s", s)
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	tests := []struct {
+		amount   string
+		curr     string
+		ratios   []int
+		expected []string
+	}{
+		{"10.00", "USD", []int{1, 1, 1}, []string{"3.34", "3.33", "3.33"}},
+		{"-10.00", "USD", []int{1, 1, 1}, []string{"-3.34", "-3.33", "-3.33"}},
+		{"5.00", "USD", []int{70, 30}, []string{"3.5", "1.5"}},
+		{"0.05", "USD", []int{3, 7}, []string{"0.02", "0.03"}},
+		{"0.01", "USD", []int{0, 1, 1}, []string{"0", "0.01", "0"}},
+		{"100", "JPY", []int{1, 1, 1}, []string{"34", "33", "33"}},
+		{"10.005", "USD", []int{1}, []string{"10"}},
+		{"0", "USD", []int{1, 2}, []string{"0", "0"}},
+	}
+
+	for _, test := range tests {
+		got := RequireFromString(test.curr, test.amount).Allocate(test.ratios...)
+		if len(got) != len(test.expected) {
+			t.Errorf("%s %v: expected %d parts, got %d", test.amount, test.ratios, len(test.expected), len(got))
+			continue
+		}
+		for i := range got {
+			if got[i].String() != test.expected[i] || got[i].currency.Code != test.curr {
+				t.Errorf("%s %v: expected part %d to be %s %s, got %s %s", test.amount, test.ratios, i, test.curr, test.expected[i], got[i].currency, got[i])
+			}
+		}
+	}
+
+	m := RequireFromString("USD", "1")
+	if !didPanic(func() { m.Allocate() }) {
+		t.Errorf("expected panic with no ratios")
+	}
+	if !didPanic(func() { m.Allocate(1, -1) }) {
+		t.Errorf("expected panic with a negative ratio")
+	}
+	if !didPanic(func() { m.Allocate(0, 0) }) {
+		t.Errorf("expected panic with ratios adding up to zero")
+	}
+}
+
+func TestMoney_AllocateWith(t *testing.T) {
+	tests := []struct {
+		amount   string
+		ratios   []int
+		strategy RemainderStrategy
+		expected []string
+	}{
+		// 1/7, 2/7 and 4/7 of 10 cents are 1.43, 2.86 and 5.71 cents
+		{"0.10", []int{1, 2, 4}, RemainderEarliest, []string{"0.02", "0.03", "0.05"}},
+		{"0.10", []int{1, 2, 4}, RemainderLargestRemainder, []string{"0.01", "0.03", "0.06"}},
+		{"0.10", []int{1, 2, 4}, RemainderLargestShare, []string{"0.01", "0.02", "0.07"}},
+		{"0.10", []int{1, 2, 4}, RemainderRoundRobin, []string{"0.02", "0.03", "0.05"}},
+		{"0.05", []int{1, 1, 1, 1}, RemainderRoundRobin, []string{"0.02", "0.01", "0.01", "0.01"}},
+		{"-0.10", []int{1, 2, 4}, RemainderLargestRemainder, []string{"-0.01", "-0.03", "-0.06"}},
+		{"-0.10", []int{1, 2, 4}, RemainderLargestShare, []string{"-0.01", "-0.02", "-0.07"}},
+
+		// Ties go to the earlier part
+		{"0.10", []int{1, 1, 1}, RemainderLargestRemainder, []string{"0.04", "0.03", "0.03"}},
+		{"0.10", []int{1, 2, 2}, RemainderLargestShare, []string{"0.02", "0.04", "0.04"}},
+
+		// Zero ratios get nothing
+		{"0.01", []int{0, 1, 1}, RemainderLargestRemainder, []string{"0", "0.01", "0"}},
+		{"0.01", []int{0, 1, 1}, RemainderLargestShare, []string{"0", "0.01", "0"}},
+		{"0.10", []int{1, 2, 4}, RemainderStrategy(99), []string{"0.02", "0.03", "0.05"}},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString("USD", test.amount)
+		got := m.AllocateWith(test.ratios, test.strategy)
+		if len(got) != len(test.expected) {
+			t.Errorf("%s %v %d: expected %d parts, got %d", test.amount, test.ratios, test.strategy, len(test.expected), len(got))
+			continue
+		}
+		for i := range got {
+			if got[i].String() != test.expected[i] {
+				t.Errorf("%s %v %d: expected part %d to be %s, got %s", test.amount, test.ratios, test.strategy, i, test.expected[i], got[i])
+			}
+		}
+		if sum := Sum(got[0], got[1:]...); !sum.Equal(m) {
+			t.Errorf("%s %v %d: parts add up to %s", test.amount, test.ratios, test.strategy, sum)
+		}
+	}
+
+	if !didPanic(func() { RequireFromString("USD", "1").AllocateWith(nil, RemainderLargestShare) }) {
+		t.Errorf("expected panic with no ratios")
+	}
+}
+
+func TestMoney_SplitByPercent(t *testing.T) {
+	tests := []struct {
+		amount   string
+		percents []string
+		expected []string
+	}{
+		{"100.00", []string{"70", "20", "10"}, []string{"70", "20", "10"}},
+		{"0.10", []string{"33.3", "33.3", "33.4"}, []string{"0.04", "0.03", "0.03"}},
+		{"100.00", []string{"33.333333", "33.333333", "33.333333"}, []string{"33.34", "33.33", "33.33"}},
+		{"-1.00", []string{"12.5", "87.5"}, []string{"-0.13", "-0.87"}},
+		{"0.01", []string{"0", "50", "50"}, []string{"0", "0.01", "0"}},
+		{"9.99", []string{"100"}, []string{"9.99"}},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString("USD", test.amount)
+		percents := make([]decimal.Decimal, len(test.percents))
+		for i, p := range test.percents {
+			percents[i] = decimal.RequireFromString(p)
+		}
+
+		got, err := m.SplitByPercent(percents...)
+		if err != nil {
+			t.Errorf("%s %v: unexpected error %s", test.amount, test.percents, err)
+			continue
+		}
+		for i := range got {
+			if got[i].String() != test.expected[i] || got[i].currency.Code != "USD" {
+				t.Errorf("%s %v: expected part %d to be USD %s, got %s %s", test.amount, test.percents, i, test.expected[i], got[i].currency, got[i])
+			}
+		}
+		if s := Sum(got[0], got[1:]...); !s.Equal(m) {
+			t.Errorf("%s %v: expected parts to add up, got %s", test.amount, test.percents, s)
+		}
+	}
+
+	m := RequireFromString("USD", "100")
+	for _, percents := range [][]decimal.Decimal{
+		nil,
+		{decimal.New(70, 0), decimal.New(20, 0)},
+		{decimal.New(70, 0), decimal.New(20, 0), decimal.New(11, 0)},
+		{decimal.New(110, 0), decimal.New(-10, 0)},
+		{decimal.RequireFromString("33.33"), decimal.RequireFromString("33.33"), decimal.RequireFromString("33.33")},
+	} {
+		if got, err := m.SplitByPercent(percents...); err == nil {
+			t.Errorf("%v: expected an error, got %v", percents, got)
+		}
+	}
+}
+
+func TestMoney_Installments(t *testing.T) {
+	tests := []struct {
+		curr           string
+		amount         string
+		n              int
+		remainderFirst bool
+		expected       []string
+	}{
+		{"USD", "100.00", 3, true, []string{"33.34", "33.33", "33.33"}},
+		{"USD", "100.00", 3, false, []string{"33.33", "33.33", "33.34"}},
+		{"USD", "100.00", 4, true, []string{"25", "25", "25", "25"}},
+		{"USD", "0.05", 4, false, []string{"0.01", "0.01", "0.01", "0.02"}},
+		{"USD", "0.02", 3, true, []string{"0.02", "0", "0"}},
+		{"USD", "-100.00", 3, true, []string{"-33.34", "-33.33", "-33.33"}},
+		{"JPY", "1000", 3, false, []string{"333", "333", "334"}},
+		{"USD", "9.99", 1, true, []string{"9.99"}},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString(test.curr, test.amount)
+		got, err := m.Installments(test.n, test.remainderFirst)
+		if err != nil {
+			t.Errorf("%s in %d: unexpected error %s", test.amount, test.n, err)
+			continue
+		}
+		if len(got) != len(test.expected) {
+			t.Errorf("%s in %d: expected %d installments, got %d", test.amount, test.n, len(test.expected), len(got))
+			continue
+		}
+		for i := range got {
+			if got[i].String() != test.expected[i] || got[i].currency.Code != test.curr {
+				t.Errorf("%s in %d: expected installment %d to be %s %s, got %s %s", test.amount, test.n, i, test.curr, test.expected[i], got[i].currency, got[i])
+			}
+		}
+		if s := Sum(got[0], got[1:]...); !s.Equal(m) {
+			t.Errorf("%s in %d: expected installments to add up, got %s", test.amount, test.n, s)
+		}
+	}
+
+	for _, n := range []int{0, -1} {
+		if got, err := RequireFromString("USD", "100").Installments(n, true); err == nil {
+			t.Errorf("%d: expected an error, got %v", n, got)
+		}
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{"15%", "15"},
+		{"-2.5%", "-2.5"},
+		{"0.75 %", "0.75"},
+		{" 100% ", "100"},
+		{"12.5", "12.5"},
+		{"0%", "0"},
+	}
+
+	for _, test := range tests {
+		d, err := ParsePercent(test.s)
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", test.s, err)
+		} else if d.String() != test.expected {
+			t.Errorf("%q: expected %s got %s", test.s, test.expected, d)
+		}
+	}
+
+	for _, s := range []string{"", "%", "abc%", "15%%", "1,5%", "15 percent", "%15"} {
+		if d, err := ParsePercent(s); !errors.Is(err, ErrParse) {
+			t.Errorf("%q: expected ErrParse, got %s %v", s, d, err)
+		}
+	}
+
+	if !didPanic(func() { ParsePercentMust("fifteen") }) {
+		t.Errorf("expected ParsePercentMust to panic")
+	}
+}
+
+func TestMoney_Percent(t *testing.T) {
+	tests := []struct {
+		amount   string
+		percent  string
+		expected string
+	}{
+		{"80.00", "15%", "12"},
+		{"9.99", "-2.5%", "-0.24975"},
+		{"200", "0.5%", "1"},
+		{"-50", "10%", "-5"},
+		{"50", "0%", "0"},
+	}
+
+	for _, test := range tests {
+		got := RequireFromString("USD", test.amount).Percent(ParsePercentMust(test.percent))
+		if got.String() != test.expected || got.currency.Code != "USD" {
+			t.Errorf("%s of %s: expected USD %s got %s %s", test.percent, test.amount, test.expected, got.currency, got)
+		}
+	}
+}
+
+func TestDecimal_PercentChange(t *testing.T) {
+	tests := []struct {
+		curr     string
+		m        string
+		prev     string
+		expected string
+	}{
+		{"USD", "100", "80", "25"},
+		{"USD", "60", "80", "-25"},
+		{"USD", "80", "80", "0"},
+		{"USD", "100", "30", "233.33333333333333333333"},
+	}
+
+	for _, test := range tests {
+		m := RequireFromString(test.curr, test.m)
+		prev := RequireFromString(test.curr, test.prev)
+		pc, err := m.PercentChange(prev)
+		if err != nil {
+			t.Errorf("unexpected error %s", err)
+		} else if pc.String() != test.expected {
+			t.Errorf("PercentChange(%s -> %s): expected %s got %s", prev, m, test.expected, pc)
 		}
 	}
 
-	// in case you specified a varchar in your SQL schema,
-	// the database driver will return byte slice []byte
-	valueStr := "535.666"
-	dbvalueStr := []byte(valueStr)
-	expected, err = NewFromString("???", valueStr)
-	if err != nil {
-		t.Fatal(err)
+	m := RequireFromString("USD", "100")
+	if _, err := m.PercentChange(RequireFromString("USD", "0.00")); err == nil {
+		t.Errorf("expected error for zero previous value")
+	}
+	if _, err := m.PercentChange(RequireFromString("EUR", "80")); err == nil {
+		t.Errorf("expected error for mismatched currencies")
 	}
+}
 
-	err = a.Scan(dbvalueStr)
-	if err != nil {
-		// Scan failed... no need to test result value
-		t.Errorf("a.Scan('535.666') failed with message: %s", err)
+func TestDecimal_Delta(t *testing.T) {
+	m := RequireFromString("USD", "60")
+	prev := RequireFromString("USD", "80.50")
 
-	} else {
-		// Scan succeeded... test resulting values
-		if !a.Valid {
-			t.Errorf("%s is null", a.Money)
-		} else if !a.Money.Equals(expected) {
-			t.Errorf("%v does not equal %v", a, expected)
-		}
+	d := m.Delta(prev)
+	if d.String() != "-20.5" || d.currency.Code != "USD" {
+		t.Errorf("expected USD -20.5, got %s %s", d.currency, d)
 	}
 
-	// lib/pq can also return strings
-	expected, err = NewFromString("???", valueStr)
-	if err != nil {
-		t.Fatal(err)
+	if !didPanic(func() { m.Delta(RequireFromString("EUR", "80")) }) {
+		t.Errorf("expected panic for mismatched currencies")
 	}
+}
 
-	err = a.Scan(valueStr)
-	if err != nil {
-		// Scan failed... no need to test result value
-		t.Errorf("a.Scan('535.666') failed with message: %s", err)
-	} else {
-		// Scan succeeded... test resulting values
-		if !a.Valid {
-			t.Errorf("%s is null", a.Money)
-		} else if !a.Money.Equals(expected) {
-			t.Errorf("%v does not equal %v", a, expected)
+func TestMoney_Format(t *testing.T) {
+	usd := RequireFromString("USD", "1234.5")
+	unknown := RequireFromString("???", "-7")
+
+	tests := []struct {
+		format   string
+		m        Money
+		expected string
+	}{
+		{"%s", usd, "1234.5"},
+		{"%v", usd, "1234.5"},
+		{"%+v", usd, "USD 1234.5"},
+		{"%q", usd, `"USD 1234.5"`},
+		{"%#v", usd, `money.RequireFromString("USD", "1234.5")`},
+		{"%.2s", usd, "1234.50"},
+		{"%.0v", usd, "1234"},
+		{"%.2q", usd, `"USD 1234.50"`},
+		{"%.3s", RequireFromString("USD", "1.2345"), "1.234"},
+		{"%10s", usd, "    1234.5"},
+		{"%-10s|", usd, "1234.5    |"},
+		{"%12.2s", usd, "     1234.50"},
+		{"%14q", usd, `  "USD 1234.5"`},
+		{"%3s", usd, "1234.5"},
+		{"%s", unknown, "-7"},
+		{"%+v", unknown, "-7"},
+		{"%q", unknown, `"-7"`},
+		{"%s", Money{}, "0"},
+		{"%d", usd, "%!d(money.Money=1234.5)"},
+	}
+
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, test.m); got != test.expected {
+			t.Errorf("%s: expected %s got %s", test.format, test.expected, got)
 		}
 	}
-}
 
-func TestNullMoney_Value(t *testing.T) {
-	// Make sure this does implement the database/sql's driver.Valuer interface
-	// check that normal case is handled appropriately
-	nm1, _ := New("???", 1234, -2)
+	// Fields of structs too
+	type line struct {
+		Item  string
+		Price Money
+	}
+	if got := fmt.Sprintf("%+v", line{"Tea", usd}); got != "{Item:Tea Price:USD 1234.5}" {
+		t.Errorf("expected {Item:Tea Price:USD 1234.5} got %s", got)
+	}
+}
 
-	a := NullMoney{Money: nm1, Valid: true}
+func TestDecimal_ZeroString(t *testing.T) {
+	fresh, _ := New("USD", 0, 0)
+	scaled, _ := New("USD", 0, 5)
+	parsed := RequireFromString("USD", "-0.00")
 
-	var NullMoney NullMoney
-	if _, ok := interface{}(NullMoney).(driver.Valuer); !ok {
-		t.Error("NullMoney does not implement driver.Valuer")
+	tests := []Money{
+		{},
+		fresh,
+		fresh.Neg(),
+		scaled,
+		parsed,
+		parsed.Neg(),
+		RequireFromString("USD", "-0.001").Round(2),
 	}
 
-	// check that null is handled appropriately
-	value, err := NullMoney.Value()
-	if err != nil {
-		t.Errorf("NullMoney{}.Valid() failed with message: %s", err)
-	} else if value != nil {
-		t.Errorf("%v is not nil", value)
+	for _, m := range tests {
+		if m.String() != "0" {
+			t.Errorf("expected 0, got %s", m.String())
+		}
+		if m.StringFixed(2) != "0.00" {
+			t.Errorf("expected 0.00, got %s", m.StringFixed(2))
+		}
+		if m.Sign() != 0 {
+			t.Errorf("expected sign 0, got %d", m.Sign())
+		}
 	}
 
-	expected := "12.34"
-	value, err = a.Value()
-	if err != nil {
-		t.Errorf("NullMoney(12.34).Value() failed with message: %s", err)
-	} else if value.(string) != expected {
-		t.Errorf("%v does not equal %v", a, expected)
+	if s := RequireFromString("USD", "-0.001").FormattedStringBank(); s != "$0.00" {
+		t.Errorf("expected $0.00, got %s", s)
 	}
 }
 
-func TestBinary(t *testing.T) {
-	for _, y := range testTable {
-		x := y.float
-
-		// Create the decimal
-		d1, _ := NewFromFloat("???", x)
+func TestMoneyJSON(t *testing.T) {
+	for _, x := range testTable {
+		s := x.short
+		var doc struct {
+			Amount Money `json:"amount"`
+		}
+		docStr := `{"amount":{"amount":"` + s + `","currency":"USD"}}`
+		docStrNumber := `{"amount":{"amount":` + s + `,"currency":"USD"}}`
+
+		// Marshaled with at least the 2 places USD has
+		fixed := s
+		if i := strings.IndexByte(s, '.'); i < 0 {
+			fixed += ".00"
+		} else if places := len(s) - i - 1; places < 2 {
+			fixed += strings.Repeat("0", 2-places)
+		}
+		outStr := `{"amount":{"amount":"` + fixed + `","currency":"USD"}}`
+		outStrNumber := `{"amount":{"amount":` + fixed + `,"currency":"USD"}}`
+
+		for _, in := range []string{docStr, docStrNumber} {
+			err := json.Unmarshal([]byte(in), &doc)
+			if err != nil {
+				t.Errorf("error unmarshaling %s: %v", in, err)
+			} else if doc.Amount.String() != s || doc.Amount.currency.Code != "USD" {
+				t.Errorf("expected USD %s, got %s %s", s, doc.Amount.currency, doc.Amount.String())
+			}
+		}
 
-		// Encode to binary
-		b, err := d1.MarshalBinary()
+		out, err := json.Marshal(&doc)
 		if err != nil {
-			t.Errorf("error marshalling %v to binary: %v", d1, err)
+			t.Errorf("error marshaling %+v: %v", doc, err)
+		} else if string(out) != outStr {
+			t.Errorf("expected %s, got %s", outStr, string(out))
 		}
-		// Restore from binary
-		var d2 Money
-		err = (&d2).UnmarshalBinary(b)
+
+		// make sure unquoted marshalling works too
+		MarshalJSONWithoutQuotes = true
+		out, err = json.Marshal(&doc)
 		if err != nil {
-			t.Errorf("error unmarshalling from binary: %v", err)
+			t.Errorf("error marshaling %+v: %v", doc, err)
+		} else if string(out) != outStrNumber {
+			t.Errorf("expected %s, got %s", outStrNumber, string(out))
 		}
+		MarshalJSONWithoutQuotes = false
 
-		// The restored decimal should equal the original
-		if !d1.Equals(d2) {
-			t.Errorf("expected %v when restoring, got %v", d1, d2)
+		// and without the fixed fraction, it's just as it was read
+		MarshalJSONFixedFraction = false
+		out, err = json.Marshal(&doc)
+		if err != nil {
+			t.Errorf("error marshaling %+v: %v", doc, err)
+		} else if string(out) != docStr {
+			t.Errorf("expected %s, got %s", docStr, string(out))
 		}
+		MarshalJSONFixedFraction = true
 	}
 }
 
-func slicesEqual(a, b []byte) bool {
-	for i, val := range a {
-		if b[i] != val {
-			return false
+func TestMoneyJSON_FixedFraction(t *testing.T) {
+	tests := []struct {
+		m        Money
+		expected string
+	}{
+		{RequireFromString("USD", "1.5"), `{"amount":"1.50","currency":"USD"}`},
+		{RequireFromString("USD", "1.500000"), `{"amount":"1.50","currency":"USD"}`},
+		{RequireFromString("USD", "150e-2"), `{"amount":"1.50","currency":"USD"}`},
+		{RequireFromString("USD", "0"), `{"amount":"0.00","currency":"USD"}`},
+		{RequireFromString("USD", "-0.00"), `{"amount":"0.00","currency":"USD"}`},
+		{RequireFromString("USD", "-1234.5"), `{"amount":"-1234.50","currency":"USD"}`},
+		{RequireFromString("USD", "1.2345"), `{"amount":"1.2345","currency":"USD"}`},
+		{RequireFromString("JPY", "1000"), `{"amount":"1000","currency":"JPY"}`},
+		{RequireFromString("JPY", "1e3"), `{"amount":"1000","currency":"JPY"}`},
+		{RequireFromString("BHD", "2.1"), `{"amount":"2.100","currency":"BHD"}`},
+		{RequireFromString("EUR", "12345678901234567890.1"), `{"amount":"12345678901234567890.10","currency":"EUR"}`},
+	}
+
+	for _, test := range tests {
+		out, err := json.Marshal(test.m)
+		if err != nil {
+			t.Errorf("%s %s: unexpected error %s", test.m.currency, test.m, err)
+		} else if string(out) != test.expected {
+			t.Errorf("expected %s, got %s", test.expected, out)
+		}
+
+		var back Money
+		if err := json.Unmarshal(out, &back); err != nil || !back.Equal(test.m) {
+			t.Errorf("%s: expected to read back %s, got %s (%v)", out, test.m, back, err)
 		}
 	}
-	return true
+
+	MarshalJSONWithoutQuotes = true
+	defer func() { MarshalJSONWithoutQuotes = false }()
+	if out, _ := json.Marshal(RequireFromString("USD", "3")); string(out) != `{"amount":3.00,"currency":"USD"}` {
+		t.Errorf("expected unquoted 3.00, got %s", out)
+	}
+
+	MarshalJSONFixedFraction = false
+	defer func() { MarshalJSONFixedFraction = true }()
+	if out, _ := json.Marshal(RequireFromString("USD", "1.500")); string(out) != `{"amount":1.5,"currency":"USD"}` {
+		t.Errorf("expected trimmed 1.5, got %s", out)
+	}
 }
 
-func TestGobEncode(t *testing.T) {
-	for _, y := range testTable {
-		x := y.float
-		d1, _ := NewFromFloat("???", x)
+func TestMoneyJSON_MapKey(t *testing.T) {
+	in := map[Money]int{
+		RequireFromString("USD", "123.45"): 1,
+		RequireFromString("EUR", "-1.5"):   2,
+		RequireFromString("JPY", "1000"):   3,
+	}
 
-		b1, err := d1.GobEncode()
-		if err != nil {
-			t.Errorf("error encoding %v to binary: %v", d1, err)
+	out, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	// Keys are sorted by their text
+	expected := `{"EUR -1.5":2,"JPY 1000":3,"USD 123.45":1}`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+
+	var got map[Money]int
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if len(got) != len(in) {
+		t.Fatalf("expected %d entries, got %d", len(in), len(got))
+	}
+
+	// The decoded keys are new values, so match them up by Key
+	byKey := map[string]int{}
+	for m, v := range got {
+		byKey[m.Key()] = v
+	}
+	for m, v := range in {
+		if byKey[m.Key()] != v {
+			t.Errorf("expected %s => %d, got %d", m.Key(), v, byKey[m.Key()])
 		}
+	}
 
-		d2, _ := NewFromFloat("???", x)
+	if err := json.Unmarshal([]byte(`{"XXXX 1":1}`), &got); err == nil {
+		t.Errorf("expected error for an unknown currency key")
+	}
+}
 
-		b2, err := d2.GobEncode()
+func TestMoneyJSON_NoCurrency(t *testing.T) {
+	var m Money
+	if err := json.Unmarshal([]byte(`{"amount":"1.23"}`), &m); err != nil {
+		t.Errorf("error unmarshaling: %v", err)
+	} else if m.currency.Code != UnknownCurrencyCode || m.String() != "1.23" {
+		t.Errorf("expected ??? 1.23, got %s %s", m.currency, m)
+	}
+}
+
+func TestMoneyJSON_Precision(t *testing.T) {
+	// Amounts well beyond what a float64 can hold
+	for _, s := range []string{
+		"12345678901234567890.12",
+		"0.12345678901234567890123",
+	} {
+		m := RequireFromString("USD", s)
+
+		MarshalJSONWithoutQuotes = true
+		out, err := json.Marshal(m)
+		MarshalJSONWithoutQuotes = false
 		if err != nil {
-			t.Errorf("error encoding %v to binary: %v", d2, err)
+			t.Fatalf("error marshaling %s: %v", s, err)
 		}
 
-		if !slicesEqual(b1, b2) {
-			t.Errorf("something about the gobencode is not working properly \n%v\n%v", b1, b2)
+		// We write the exact digits, and read them back exactly...
+		var back Money
+		if err := json.Unmarshal(out, &back); err != nil {
+			t.Errorf("error unmarshaling %s: %v", out, err)
+		} else if back.String() != s {
+			t.Errorf("expected %s, got %s", s, back)
 		}
 
-		var d3 Money
-		err = d3.GobDecode(b1)
-		if err != nil {
-			t.Errorf("Error gobdecoding %v, got %v", b1, d3)
-		}
-		var d4 Money
-		err = d4.GobDecode(b2)
-		if err != nil {
-			t.Errorf("Error gobdecoding %v, got %v", b2, d4)
+		// ...but a consumer decoding numbers as float64 won't
+		var generic map[string]interface{}
+		if err := json.Unmarshal(out, &generic); err != nil {
+			t.Errorf("error unmarshaling %s: %v", out, err)
+		} else if f, ok := generic["amount"].(float64); !ok || strconv.FormatFloat(f, 'f', -1, 64) == s {
+			t.Errorf("expected %s to lose precision as a float64, got %v", s, generic["amount"])
 		}
 
-		eq := d3.Equal(d4)
-		if eq != true {
-			t.Errorf("Encoding then decoding mutated Decimal")
+		// Quoted (the default) survives the same treatment
+		out, _ = json.Marshal(m)
+		if err := json.Unmarshal(out, &generic); err != nil {
+			t.Errorf("error unmarshaling %s: %v", out, err)
+		} else if generic["amount"] != s {
+			t.Errorf("expected %s, got %v", s, generic["amount"])
 		}
+	}
+}
 
-		eq = d1.Equal(d3)
-		if eq != true {
-			t.Errorf("Error gobencoding/decoding %v, got %v", d1, d3)
+func TestBadJSON(t *testing.T) {
+	for _, testCase := range []string{
+		"]o_o[",
+		"{",
+		`{"amount":""`,
+		`{"amount":""}`,
+		`{"amount":"nope"}`,
+		`{"amount":"1.23","currency":"I*am*Not*a*Currency"}`,
+		`0.333`,
+	} {
+		var m Money
+		err := json.Unmarshal([]byte(testCase), &m)
+		if err == nil {
+			t.Errorf("expected error, got %+v", m)
 		}
 	}
 }
 
-func TestSum(t *testing.T) {
-	vals := make([]Money, 10)
-	var i = int64(0)
+func TestNullMoneyJSONNull(t *testing.T) {
+	var doc struct {
+		Amount NullMoney `json:"amount"`
+	}
+	docStr := `{"amount":null}`
+	if err := json.Unmarshal([]byte(docStr), &doc); err != nil {
+		t.Errorf("error unmarshaling %s: %v", docStr, err)
+	} else if doc.Amount.Valid {
+		t.Errorf("expected null, got %s", doc.Amount.Money)
+	}
 
-	for key := range vals {
-		vals[key], _ = New("???", i, 0)
-		i++
+	out, err := json.Marshal(&doc)
+	if err != nil {
+		t.Errorf("error marshaling %+v: %v", doc, err)
+	} else if string(out) != docStr {
+		t.Errorf("expected %s, got %s", docStr, string(out))
 	}
 
-	sum := Sum(vals[0], vals[1:]...)
-	tm1, _ := New("???", 45, 0)
-	if !sum.Equal(tm1) {
-		t.Errorf("Failed to calculate sum, expected %s got %s", tm1, sum)
+	docStr = `{"amount":{"amount":"1.5","currency":"EUR"}}`
+	if err := json.Unmarshal([]byte(docStr), &doc); err != nil {
+		t.Errorf("error unmarshaling %s: %v", docStr, err)
+	} else if !doc.Amount.Valid || doc.Amount.Money.String() != "1.5" {
+		t.Errorf("expected 1.5, got %s", doc.Amount.Money)
 	}
 }
 
-func TestAvg(t *testing.T) {
-	vals := make([]Money, 10)
-	var i = int64(0)
-
-	for key := range vals {
-		vals[key], _ = New("???", i, 0)
-		i++
+func TestStringUnits(t *testing.T) {
+	tests := []struct {
+		curr     string
+		amount   string
+		expected string
+	}{
+		{"USD", "12.99", "12 dollars and 99 cents"},
+		{"USD", "1.01", "1 dollar and 1 cent"},
+		{"USD", "0.00", "0 dollars and 0 cents"},
+		{"USD", "2", "2 dollars and 0 cents"},
+		{"USD", "-12.99", "minus 12 dollars and 99 cents"},
+		{"USD", "-0.001", "0 dollars and 0 cents"},
+		{"USD", "1.015", "1 dollar and 2 cents"},
+		{"GBP", "3.01", "3 pounds and 1 penny"},
+		{"GBP", "1.50", "1 pound and 50 pence"},
+		{"JPY", "500", "500 yen"},
+		{"JPY", "1", "1 yen"},
+		{"BHD", "1.005", "1 dinar and 5 fils"},
+		{"BTC", "1.5", "1.50000000 BTC"},
 	}
 
-	avg := Avg(vals[0], vals[1:]...)
-	check, _ := NewFromFloat("???", 4.5)
-	if !avg.Equal(check) {
-		t.Errorf("Failed to calculate average, expected %s got %s", check, avg.String())
+	for _, test := range tests {
+		m := RequireFromString(test.curr, test.amount)
+		if s := m.StringUnits(); s != test.expected {
+			t.Errorf("%s %s: expected %q got %q", test.curr, test.amount, test.expected, s)
+		}
 	}
 }
 
-func TestRoundBankAnomaly(t *testing.T) {
-	a, _ := New("???", 25, -1)
-	b, _ := New("???", 250, -2)
-
-	if !a.Equal(b) {
-		t.Errorf("Expected %s to equal %s", a, b)
+func TestKey(t *testing.T) {
+	tests := []struct {
+		curr     string
+		amount   string
+		expected string
+	}{
+		{"USD", "1.5", "USD:1.50"},
+		{"USD", "1.50", "USD:1.50"},
+		{"USD", "1.500000", "USD:1.50"},
+		{"USD", "1.005", "USD:1.005"},
+		{"USD", "-0.00", "USD:0.00"},
+		{"USD", "1e3", "USD:1000.00"},
+		{"EUR", "1.5", "EUR:1.50"},
+		{"JPY", "1234", "JPY:1234"},
+		{"BTC", "0.5", "BTC:0.50000000"},
 	}
 
-	expected, _ := New("???", 2, 0)
-
-	aRounded := a.RoundBank(0)
-	if !aRounded.Equal(expected) {
-		t.Errorf("Expected bank rounding %s to equal %s, but it was %s", a, expected, aRounded)
+	for _, test := range tests {
+		if k := RequireFromString(test.curr, test.amount).Key(); k != test.expected {
+			t.Errorf("%s %s: expected key %s got %s", test.curr, test.amount, test.expected, k)
+		}
 	}
 
-	bRounded := b.RoundBank(0)
-	if !bRounded.Equal(expected) {
-		t.Errorf("Expected bank rounding %s to equal %s, but it was %s", b, expected, bRounded)
+	set := map[string]Money{}
+	for _, m := range []Money{
+		RequireFromString("USD", "1.5"),
+		RequireFromString("USD", "1.50"),
+		RequireFromString("EUR", "1.5"),
+		RequireFromString("USD", "1.005"),
+		RequireFromString("USD", "1.01"),
+	} {
+		set[m.Key()] = m
+	}
+	if len(set) != 4 {
+		t.Errorf("expected 4 distinct keys, got %d", len(set))
 	}
 }
 
-func TestFormattedStrings(t *testing.T) {
-	for _, x := range testTable {
-		s := x.exact
-		aud := x.asAUD
-		d, err := NewFromString("AUD", s)
-		if err != nil {
-			t.Errorf("error while parsing %s", s)
-		} else if aud != "N/A" && d.FormattedStringBank() != aud {
-			t.Errorf("expected %s, got %s (from: %s)",
-				aud, d.FormattedStringBank(),
-				d.String())
-		}
+func TestCanonical(t *testing.T) {
+	counts := map[Canonical]int{}
+	for _, m := range []Money{
+		RequireFromString("USD", "1.5"),
+		RequireFromString("USD", "1.50"),
+		RequireFromString("USD", "1.500"),
+		RequireFromString("EUR", "1.5"),
+		RequireFromString("USD", "1.005"),
+	} {
+		counts[m.Canonical()]++
 	}
 
-	for _, x := range testTable {
-		s := x.exact
-		acc := x.asAUDAccounting
-		d, err := NewFromString("AUD", s)
+	if len(counts) != 3 {
+		t.Errorf("expected 3 distinct entries, got %d", len(counts))
+	}
+	if n := counts[Canonical{Code: "USD", Amount: "1.50"}]; n != 3 {
+		t.Errorf("expected USD 1.5 and 1.50 to collide 3 times, got %d", n)
+	}
+	if n := counts[Canonical{Code: "EUR", Amount: "1.50"}]; n != 1 {
+		t.Errorf("expected EUR 1.50 once, got %d", n)
+	}
+
+	for c := range counts {
+		m, err := c.Money()
 		if err != nil {
-			t.Errorf("error while parsing %s", s)
-		} else {
-			d = d.Neg()
-			if acc != "N/A" && d.FormattedStringAccounting() != acc {
-				if acc == "0.00" && d.FormattedStringAccounting() == "(0.00)" {
-					// special case - we cool
-				} else {
-					t.Errorf("expected %s, got %s (from: %s)",
-						acc, d.FormattedStringAccounting(),
-						d.String())
-				}
-			}
+			t.Errorf("error converting %+v back to Money: %s", c, err)
+		} else if m.Canonical() != c {
+			t.Errorf("expected %+v to round trip, got %+v", c, m.Canonical())
 		}
 	}
 
+	if _, err := (Canonical{Code: "I*am*Not*a*Currency", Amount: "1"}).Money(); err == nil {
+		t.Errorf("expected error for unknown currency")
+	}
 }
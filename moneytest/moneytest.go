@@ -0,0 +1,37 @@
+// package moneytest - Test helpers for Money
+// Money's fields are unexported, so tests can't reach in and compare them, and
+// a bare "not equal" doesn't say what was wrong. Kept out of the core money
+// package so it doesn't import testing.
+
+package moneytest
+
+import (
+	"fmt"
+	"github.com/aaronchipper/go-money"
+	"testing"
+)
+
+// AssertEqual fails t, without stopping it, unless want and got have the same
+// currency and amount. Trailing zeros don't matter, so USD 1.5 and USD 1.50
+// are equal. The failure reads like:
+//
+//	want USD 1.50, got USD 1.05
+func AssertEqual(t testing.TB, want, got money.Money) {
+	t.Helper()
+
+	if diff := Diff(want, got); diff != "" {
+		t.Errorf("%s", diff)
+	}
+}
+
+// Diff returns a description of how got differs from want, or "" if they have
+// the same currency and amount. Amounts are written to at least the currency's
+// Fraction, so the digits line up.
+func Diff(want, got money.Money) string {
+	w, g := want.Canonical(), got.Canonical()
+	if w == g {
+		return ""
+	}
+
+	return fmt.Sprintf("want %s %s, got %s %s", w.Code, w.Amount, g.Code, g.Amount)
+}
@@ -0,0 +1,53 @@
+package moneytest
+
+import (
+	"fmt"
+	"github.com/aaronchipper/go-money"
+	"testing"
+)
+
+// recorder is a testing.TB which keeps what it's told rather than failing
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEqual(t *testing.T) {
+	tests := []struct {
+		want     money.Money
+		got      money.Money
+		expected string
+	}{
+		{money.RequireFromString("USD", "1.50"), money.RequireFromString("USD", "1.5"), ""},
+		{money.RequireFromString("USD", "0"), money.RequireFromString("USD", "-0.00"), ""},
+		{money.RequireFromString("USD", "1.50"), money.RequireFromString("USD", "1.05"), "want USD 1.50, got USD 1.05"},
+		{money.RequireFromString("USD", "1.50"), money.RequireFromString("EUR", "1.50"), "want USD 1.50, got EUR 1.50"},
+		{money.RequireFromString("USD", "1.005"), money.RequireFromString("USD", "1"), "want USD 1.005, got USD 1.00"},
+		{money.RequireFromString("JPY", "100"), money.Money{}, "want JPY 100, got ??? 0.00"},
+	}
+
+	for _, test := range tests {
+		r := &recorder{}
+		AssertEqual(r, test.want, test.got)
+
+		switch {
+		case test.expected == "" && len(r.errors) != 0:
+			t.Errorf("expected %s and %s to be equal, got %q", test.want, test.got, r.errors)
+		case test.expected != "" && (len(r.errors) != 1 || r.errors[0] != test.expected):
+			t.Errorf("expected %q, got %q", test.expected, r.errors)
+		}
+
+		if diff := Diff(test.want, test.got); diff != test.expected {
+			t.Errorf("expected Diff %q, got %q", test.expected, diff)
+		}
+	}
+
+	// And for real, when it passes
+	AssertEqual(t, money.RequireFromString("EUR", "12.3"), money.RequireFromString("EUR", "12.30"))
+}
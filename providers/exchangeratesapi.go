@@ -0,0 +1,109 @@
+// package providers - RateProviders backed by real rate services
+// Kept out of the core money package so that it doesn't need net/http, or to
+// know about anyone's API.
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aaronchipper/go-money"
+	"github.com/shopspring/decimal"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ExchangeRatesAPIBaseURL is where exchangeratesapi.io serves its latest rates.
+const ExchangeRatesAPIBaseURL = "https://api.exchangeratesapi.io/v1/latest"
+
+// ExchangeRatesAPIProvider is a money.RateProvider which looks up the latest
+// rates from exchangeratesapi.io. Every call to Rate is a request, so wrap it in
+// a money.CachingRateProvider unless you like paying for API calls.
+type ExchangeRatesAPIProvider struct {
+	APIKey  string
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewExchangeRatesAPIProvider creates an ExchangeRatesAPIProvider using apiKey.
+// If client is nil, http.DefaultClient is used.
+func NewExchangeRatesAPIProvider(apiKey string, client *http.Client) *ExchangeRatesAPIProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &ExchangeRatesAPIProvider{
+		APIKey:  apiKey,
+		BaseURL: ExchangeRatesAPIBaseURL,
+		Client:  client,
+	}
+}
+
+// exchangeRatesAPIResponse is the body of a latest rates response. Rates are
+// kept as json.Numbers so they don't lose anything by passing through a float64.
+type exchangeRatesAPIResponse struct {
+	Success bool                   `json:"success"`
+	Base    string                 `json:"base"`
+	Rates   map[string]json.Number `json:"rates"`
+	Error   *struct {
+		Code int    `json:"code"`
+		Type string `json:"type"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+// Rate implements the money.RateProvider interface.
+func (p *ExchangeRatesAPIProvider) Rate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+
+	q := url.Values{}
+	q.Set("access_key", p.APIKey)
+	q.Set("base", from)
+	q.Set("symbols", to)
+
+	req, err := http.NewRequest(http.MethodGet, p.BaseURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("Cannot fetch rate [%s] to [%s]: %w", from, to, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("Cannot fetch rate [%s] to [%s]: %w", from, to, err)
+	}
+
+	var r exchangeRatesAPIResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return decimal.Zero, fmt.Errorf("Cannot fetch rate [%s] to [%s]: HTTP %s", from, to, resp.Status)
+		}
+		return decimal.Zero, fmt.Errorf("Cannot decode rate [%s] to [%s]: %s", from, to, err)
+	}
+
+	// The API reports its own errors in the body, usually with a 200
+	if r.Error != nil {
+		return decimal.Zero, fmt.Errorf("Cannot fetch rate [%s] to [%s]: %s (%d): %s", from, to, r.Error.Type, r.Error.Code, r.Error.Info)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return decimal.Zero, fmt.Errorf("Cannot fetch rate [%s] to [%s]: HTTP %s", from, to, resp.Status)
+	}
+
+	n, ok := r.Rates[to]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("Cannot convert [%s] to [%s]: %w", from, to, money.ErrNoRate)
+	}
+
+	rate, err := decimal.NewFromString(n.String())
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("Cannot decode rate [%s] to [%s]: %s", from, to, err)
+	}
+
+	return rate, nil
+}
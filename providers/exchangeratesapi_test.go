@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"github.com/aaronchipper/go-money"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testServer(t *testing.T, status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_key") != "secret" {
+			t.Errorf("expected access_key secret, got %s", r.URL.Query().Get("access_key"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestExchangeRatesAPIProvider_Rate(t *testing.T) {
+	srv := testServer(t, http.StatusOK, `{"success":true,"timestamp":1519296206,"base":"EUR","date":"2021-03-17","rates":{"USD":1.192857,"JPY":129.857143123456789}}`)
+	defer srv.Close()
+
+	p := NewExchangeRatesAPIProvider("secret", srv.Client())
+	p.BaseURL = srv.URL
+
+	tests := []struct {
+		to       string
+		expected string
+	}{
+		{"USD", "1.192857"},
+		{"JPY", "129.857143123456789"},
+	}
+
+	for _, test := range tests {
+		rate, err := p.Rate(context.Background(), "EUR", test.to)
+		if err != nil {
+			t.Errorf("EUR -> %s: unexpected error %s", test.to, err)
+		} else if rate.String() != test.expected {
+			t.Errorf("EUR -> %s: expected %s got %s", test.to, test.expected, rate)
+		}
+	}
+
+	// Not in the response
+	if _, err := p.Rate(context.Background(), "EUR", "GBP"); !errors.Is(err, money.ErrNoRate) {
+		t.Errorf("expected ErrNoRate, got %v", err)
+	}
+}
+
+func TestExchangeRatesAPIProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"api error", http.StatusOK, `{"success":false,"error":{"code":101,"type":"invalid_access_key","info":"You have not supplied a valid API Access Key."}}`},
+		{"http error", http.StatusInternalServerError, `Internal Server Error`},
+		{"bad json", http.StatusOK, `{"success":true,"rates":`},
+		{"bad rate", http.StatusOK, `{"success":true,"rates":{"USD":"lots"}}`},
+	}
+
+	for _, test := range tests {
+		srv := testServer(t, test.status, test.body)
+
+		p := NewExchangeRatesAPIProvider("secret", srv.Client())
+		p.BaseURL = srv.URL
+
+		_, err := p.Rate(context.Background(), "EUR", "USD")
+		if err == nil {
+			t.Errorf("%s: expected error", test.name)
+		} else if errors.Is(err, money.ErrNoRate) {
+			t.Errorf("%s: expected a failure, not ErrNoRate", test.name)
+		}
+
+		srv.Close()
+	}
+}
+
+func TestExchangeRatesAPIProvider_Exchanger(t *testing.T) {
+	srv := testServer(t, http.StatusOK, `{"success":true,"base":"EUR","rates":{"USD":1.2}}`)
+	defer srv.Close()
+
+	p := NewExchangeRatesAPIProvider("secret", srv.Client())
+	p.BaseURL = srv.URL
+
+	got, err := money.NewExchanger(p, "").Convert(context.Background(), money.RequireFromString("EUR", "10"), "USD")
+	if err != nil {
+		t.Errorf("unexpected error %s", err)
+	} else if got.String() != "12" {
+		t.Errorf("expected 12, got %s", got)
+	}
+}
@@ -0,0 +1,158 @@
+// package money - Rounding modes
+// The decimal package gives us Round (half away from zero) and RoundBank
+// (half to even), but money code often needs to pick the rule at runtime, so
+// the modes are wrapped up here.
+
+package money
+
+import (
+	"github.com/shopspring/decimal"
+	"math"
+	"math/big"
+)
+
+// RoundingMode decides what happens to digits that don't fit when an amount
+// is brought back to a fixed number of decimal places.
+type RoundingMode int
+
+// Rounding modes available.
+const (
+	RoundHalfEven RoundingMode = iota //	RoundHalfEven	(banker's rounding. 2.125 => 2.12, 2.135 => 2.14)
+	RoundHalfUp                       //	RoundHalfUp	(half away from zero. 2.125 => 2.13, -2.125 => -2.13)
+	RoundDown                         //	RoundDown	(towards zero, aka truncate. 2.129 => 2.12, -2.129 => -2.12)
+	RoundUp                           //	RoundUp	(away from zero. 2.121 => 2.13, -2.121 => -2.13)
+	RoundFloor                        //	RoundFloor	(towards negative infinity. 2.129 => 2.12, -2.121 => -2.13)
+	RoundCeil                         //	RoundCeil	(towards positive infinity. 2.121 => 2.13, -2.129 => -2.12)
+)
+
+// DefaultRoundingMode is used whenever the package has to round an amount to a
+// currency's Fraction on your behalf. Defaults to banker's rounding, which is
+// also what the formatter uses for display. Pass it to FormatCurrencyMode or
+// FormattedStringMode to display amounts the same way when it's changed.
+var DefaultRoundingMode = RoundHalfEven
+
+// roundDecimal rounds d to places decimal places using the given mode.
+func roundDecimal(d decimal.Decimal, places int32, mode RoundingMode) decimal.Decimal {
+	return divRoundDecimal(d, decimal.New(1, 0), places, mode)
+}
+
+// divRoundDecimal returns d / d2 rounded to places decimal places using the
+// given mode. The rounding decision is made on the exact remainder, so there's
+// no double rounding through DivisionPrecision.
+//
+// NOTE: This will panic if d2 is zero, as per the decimal package.
+func divRoundDecimal(d, d2 decimal.Decimal, places int32, mode RoundingMode) decimal.Decimal {
+
+	// q is truncated towards zero, r carries the sign of d
+	q, r := d.QuoRem(d2, places)
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// One unit in the last place, pointing away from zero
+	step := decimal.New(1, -places)
+	negative := d.Sign() != d2.Sign()
+	if negative {
+		step = step.Neg()
+	}
+
+	// Compare the remainder against half a unit in the last place
+	half := r.Abs().Shift(1).Cmp(d2.Abs().Mul(decimal.New(5, -places)))
+
+	away := false
+	switch mode {
+	case RoundHalfEven:
+		away = half > 0 || (half == 0 && q.Coefficient().Bit(0) == 1)
+	case RoundHalfUp:
+		away = half >= 0
+	case RoundDown:
+		away = false
+	case RoundUp:
+		away = true
+	case RoundFloor:
+		away = negative
+	case RoundCeil:
+		away = !negative
+	}
+
+	if away {
+		return q.Add(step)
+	}
+	return q
+}
+
+// maxCashCoefficient keeps every step of roundCashDecimal's int64 sums clear
+// of overflow.
+const maxCashCoefficient = math.MaxInt64/100 - 1
+
+// roundCashDecimal rounds d to the nearest multiple of interval hundredths,
+// with halves away from zero, giving two decimal places. It's what the decimal
+// package's d.Mul(k).Round(0).Div(k).Truncate(2) works out, but done on the
+// coefficient, in int64s when it fits, rather than through a chain of
+// intermediate Decimals. 15 is 10 cent rounding with halves towards zero.
+//
+// The interval must be 5, 10, 15, 20, 25, 50 or 100.
+func roundCashDecimal(d decimal.Decimal, interval uint8) decimal.Decimal {
+	step := int64(interval)
+	halfAway := true
+	if interval == 15 {
+		step, halfAway = 10, false
+	}
+	per := 100 / step // steps per unit, ie. 20 for 5 cent rounding
+
+	exp := d.Exponent()
+	co := d.Coefficient()
+
+	if exp <= 0 && exp >= -18 && co.IsInt64() {
+		if c := co.Int64(); c > -maxCashCoefficient && c < maxCashCoefficient {
+			p := int64(1)
+			for i := exp; i < 0; i++ {
+				p *= 10
+			}
+
+			n := c * per
+			q, r := n/p, n%p
+			if r < 0 {
+				r = -r
+			}
+			if 2*r > p || (halfAway && 2*r == p) {
+				if n < 0 {
+					q--
+				} else {
+					q++
+				}
+			}
+
+			return decimal.New(q*step, -2)
+		}
+	}
+
+	// Too big for int64, so the same sums in big.Int
+	n := co.Mul(co, big.NewInt(per))
+	if exp > 0 {
+		n.Mul(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	} else if exp < 0 {
+		p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil)
+		r := new(big.Int)
+		n.QuoRem(n, p, r)
+		if half := r.Abs(r).Lsh(r, 1).Cmp(p); half > 0 || (halfAway && half == 0) {
+			if d.Sign() < 0 {
+				n.Sub(n, big.NewInt(1))
+			} else {
+				n.Add(n, big.NewInt(1))
+			}
+		}
+	}
+
+	return decimal.NewFromBigInt(n.Mul(n, big.NewInt(step)), -2)
+}
+
+// roundToFraction rounds the Money to its currency's Fraction using mode.
+func (m Money) roundToFraction(mode RoundingMode) Money {
+	m.ensureInitialized()
+
+	return Money{
+		amount:   roundDecimal(m.amount, int32(m.currency.Fraction), mode),
+		currency: m.currency,
+	}
+}
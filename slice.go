@@ -0,0 +1,41 @@
+// package money - Sorting
+// Sorting Moneys needs a Cmp, and Cmp panics on mixed currencies, which is no
+// way to find out halfway through a sort. MoneySlice keeps each currency
+// together instead.
+
+package money
+
+import (
+	"sort"
+)
+
+// MoneySlice attaches the methods of sort.Interface to []Money, sorting in
+// increasing order:
+//
+//	sort.Sort(MoneySlice(ms))
+//
+// Moneys of the same currency are ordered by amount. Mixed currencies never
+// panic: they're grouped by currency code, in alphabetical order, and sorted
+// by amount within each group, so EUR 100 comes before USD 1.
+type MoneySlice []Money
+
+func (s MoneySlice) Len() int { return len(s) }
+
+func (s MoneySlice) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	a.ensureInitialized()
+	b.ensureInitialized()
+
+	if a.currency.Code != b.currency.Code {
+		return a.currency.Code < b.currency.Code
+	}
+	return a.amount.Cmp(b.amount) < 0
+}
+
+func (s MoneySlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// SortStable sorts s in place, keeping equal Moneys, ie. 1.5 and 1.50, in the
+// order they were in.
+func (s MoneySlice) SortStable() {
+	sort.Stable(s)
+}
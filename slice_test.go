@@ -0,0 +1,61 @@
+package money
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func moneyStrings(ms []Money) []string {
+	ss := make([]string, len(ms))
+	for i, m := range ms {
+		m.ensureInitialized()
+		ss[i] = m.currency.Code + " " + m.String()
+	}
+	return ss
+}
+
+func TestMoneySlice(t *testing.T) {
+	ms := usdAmounts("10", "-2.5", "0", "100", "3.33", "-0.01")
+	sort.Sort(MoneySlice(ms))
+
+	expected := []string{"USD -2.5", "USD -0.01", "USD 0", "USD 3.33", "USD 10", "USD 100"}
+	if got := moneyStrings(ms); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestMoneySlice_SortStable(t *testing.T) {
+	ms := make([]Money, 4)
+	ms[0], _ = New("USD", 150, -2)
+	ms[1], _ = New("USD", 1, 0)
+	ms[2], _ = New("USD", 15, -1)
+	ms[3], _ = New("USD", 1500, -3)
+	MoneySlice(ms).SortStable()
+
+	expected := []int32{0, -2, -1, -3}
+	for i, m := range ms {
+		if m.Exponent() != expected[i] {
+			t.Errorf("expected %v exponents, got %s (%d) at %d", expected, m, m.Exponent(), i)
+		}
+	}
+}
+
+func TestMoneySlice_MixedCurrencies(t *testing.T) {
+	ms := []Money{
+		RequireFromString("USD", "1"),
+		RequireFromString("EUR", "100"),
+		{},
+		RequireFromString("USD", "-5"),
+		RequireFromString("JPY", "500"),
+		RequireFromString("EUR", "-1"),
+	}
+
+	// Grouped by code, then by amount, and no panic
+	MoneySlice(ms).SortStable()
+
+	expected := []string{"??? 0", "EUR -1", "EUR 100", "JPY 500", "USD -5", "USD 1"}
+	if got := moneyStrings(ms); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,30 @@
+// package money - Template helpers
+// Formatting functions for text/template and html/template, so views can write
+// {{ .Price | currency }} rather than calling the formatters themselves.
+
+package money
+
+import (
+	"text/template"
+)
+
+// FuncMap returns the Money formatting functions for use in templates:
+//
+//	currency    FormattedStringBank, ie. "$1,234.56"
+//	accounting  FormattedStringAccounting, ie. "(1234.56)"
+//	amount      AmountString, ie. "1234.56"
+//
+// It works with both text/template and html/template:
+//
+//	t := template.Must(template.New("price").Funcs(money.FuncMap()).Parse(`{{ .Price | currency }}`))
+//
+// The functions return plain strings rather than template.HTML, so
+// html/template escapes them like any other value; a grapheme with markup in
+// it can't break the page.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"currency":   Money.FormattedStringBank,
+		"accounting": Money.FormattedStringAccounting,
+		"amount":     Money.AmountString,
+	}
+}
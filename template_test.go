@@ -0,0 +1,51 @@
+package money
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	const tmpl = `{{ .Price | currency }}|{{ .Price | accounting }}|{{ amount .Price }}`
+
+	tests := []struct {
+		price    Money
+		expected string
+	}{
+		{RequireFromString("USD", "1234.5"), "$1,234.50|1234.50|1234.50"},
+		{RequireFromString("USD", "-1234.5"), "-$1,234.50|(1234.50)|-1234.50"},
+		{RequireFromString("JPY", "500"), "\u00a5500|500|500"},
+	}
+
+	text := template.Must(template.New("text").Funcs(FuncMap()).Parse(tmpl))
+	html := htmltemplate.Must(htmltemplate.New("html").Funcs(FuncMap()).Parse(tmpl))
+
+	for _, test := range tests {
+		var buf strings.Builder
+		if err := text.Execute(&buf, struct{ Price Money }{test.price}); err != nil {
+			t.Errorf("%s: unexpected error %s", test.price, err)
+		} else if buf.String() != test.expected {
+			t.Errorf("%s: expected %q got %q", test.price, test.expected, buf.String())
+		}
+
+		buf.Reset()
+		if err := html.Execute(&buf, struct{ Price Money }{test.price}); err != nil {
+			t.Errorf("%s: unexpected error %s", test.price, err)
+		} else if buf.String() != test.expected {
+			t.Errorf("%s: expected %q got %q", test.price, test.expected, buf.String())
+		}
+	}
+
+	// html/template escapes whatever the currency puts out
+	AddCurrencyFull(Currency{Type: POINTS, Code: "#TT", Grapheme: "<b>", Template: "$1", DecPoint: ".", Fraction: 0})
+	defer delete(currencies, "#TT")
+
+	var buf strings.Builder
+	if err := html.Execute(&buf, struct{ Price Money }{RequireFromString("#TT", "5")}); err != nil {
+		t.Errorf("unexpected error %s", err)
+	} else if !strings.HasPrefix(buf.String(), "&lt;b&gt;5|") {
+		t.Errorf("expected the grapheme to be escaped, got %q", buf.String())
+	}
+}
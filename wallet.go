@@ -0,0 +1,99 @@
+// package money - Wallet
+// Moneys of different currencies can't be added together, but they can be
+// held together. A Wallet keeps one balance per currency, and uses an
+// Exchanger when you need it all in one.
+
+package money
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"sort"
+)
+
+// Wallet holds a balance in each of any number of currencies. The zero value
+// is an empty Wallet ready to use.
+//
+// Example:
+//
+//	var w Wallet
+//	w.Add(RequireFromString("USD", "10"))
+//	w.Add(RequireFromString("EUR", "5"))
+//	w.Add(RequireFromString("USD", "2.50"))
+//	w.Balance("USD").String() // output: "12.5"
+//
+// NOTE: A Wallet isn't safe for concurrent use.
+type Wallet struct {
+	balances map[string]Money
+}
+
+// Add adds m to the balance in m's currency. Different currencies go in their
+// own balances, so unlike Money.Add, it never panics.
+func (w *Wallet) Add(m Money) {
+
+	m.ensureInitialized()
+
+	if w.balances == nil {
+		w.balances = map[string]Money{}
+	}
+
+	code := m.currency.Code
+	if b, ok := w.balances[code]; ok {
+		m = Money{amount: b.amount.Add(m.amount), currency: b.currency}
+	}
+	w.balances[code] = m
+}
+
+// Balance returns the balance in the currency with the given code, which is
+// zero if nothing in that currency has been added.
+func (w *Wallet) Balance(code string) Money {
+	if b, ok := w.balances[code]; ok {
+		return b
+	}
+
+	zero, _ := New(code, 0, 0)
+	return zero
+}
+
+// Currencies returns the codes of the currencies the Wallet holds, sorted.
+func (w *Wallet) Currencies() []string {
+	codes := make([]string, 0, len(w.balances))
+	for code := range w.balances {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+// Total converts every balance into the to currency using e, and adds them
+// up. As with Exchanger.Convert, the result isn't rounded. An empty Wallet
+// totals zero.
+//
+// An error is returned if to isn't a known currency, or any of the balances
+// can't be converted.
+func (w *Wallet) Total(ctx context.Context, e *Exchanger, to string) (Money, error) {
+
+	c, ok := GetCurrency(to)
+	if !ok {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, &UnsupportedCurrencyError{Code: to}
+	}
+
+	codes := w.Currencies()
+	ms := make([]Money, len(codes))
+	for i, code := range codes {
+		ms[i] = w.balances[code]
+	}
+
+	converted, err := e.ConvertAll(ctx, ms, to)
+	if err != nil {
+		return Money{amount: decimal.Zero, currency: getBadCurrency()}, err
+	}
+
+	total := Money{amount: decimal.Zero, currency: c}
+	for _, m := range converted {
+		total.amount = total.amount.Add(m.amount)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,84 @@
+package money
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"testing"
+)
+
+func TestWallet(t *testing.T) {
+	var w Wallet
+
+	if b := w.Balance("USD"); b.String() != "0" || b.currency.Code != "USD" {
+		t.Errorf("expected USD 0 when empty, got %s %s", b.currency, b)
+	}
+
+	w.Add(RequireFromString("USD", "10"))
+	w.Add(RequireFromString("EUR", "5"))
+	w.Add(RequireFromString("USD", "2.50"))
+	w.Add(RequireFromString("JPY", "1000"))
+	w.Add(RequireFromString("EUR", "-1.25"))
+
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{"USD", "12.5"},
+		{"EUR", "3.75"},
+		{"JPY", "1000"},
+		{"GBP", "0"},
+	}
+
+	for _, test := range tests {
+		if b := w.Balance(test.code); b.String() != test.expected || b.currency.Code != test.code {
+			t.Errorf("expected %s %s, got %s %s", test.code, test.expected, b.currency, b)
+		}
+	}
+
+	if codes := w.Currencies(); !reflect.DeepEqual(codes, []string{"EUR", "JPY", "USD"}) {
+		t.Errorf("expected EUR, JPY and USD, got %v", codes)
+	}
+
+	// Balance hands out a copy
+	b := w.Balance("USD")
+	w.Add(RequireFromString("USD", "1"))
+	if b.String() != "12.5" || w.Balance("USD").String() != "13.5" {
+		t.Errorf("expected 12.5 and 13.5, got %s and %s", b, w.Balance("USD"))
+	}
+}
+
+func TestWallet_Total(t *testing.T) {
+	provider := testRateProvider().
+		SetRate("JPY", "USD", decimal.RequireFromString("0.0067"))
+	e := NewExchanger(provider, "")
+
+	var w Wallet
+	w.Add(RequireFromString("USD", "100"))
+	w.Add(RequireFromString("EUR", "10"))
+	w.Add(RequireFromString("JPY", "1000"))
+
+	// 100 + 10.87 + 6.7
+	total, err := w.Total(context.Background(), e, "USD")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if total.String() != "117.57" || total.currency.Code != "USD" {
+		t.Errorf("expected USD 117.57, got %s %s", total.currency, total)
+	}
+
+	// No JPY -> EUR rate
+	if _, err := w.Total(context.Background(), e, "EUR"); !errors.Is(err, ErrNoRate) {
+		t.Errorf("expected ErrNoRate, got %v", err)
+	}
+
+	if _, err := w.Total(context.Background(), e, "I*am*Not*a*Currency"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+
+	var empty Wallet
+	if total, err := empty.Total(context.Background(), e, "EUR"); err != nil || total.String() != "0" || total.currency.Code != "EUR" {
+		t.Errorf("expected EUR 0, got %s %s (%v)", total.currency, total, err)
+	}
+}